@@ -28,6 +28,13 @@ func convert_url_Values_To__ContainerReplicaExecOptions(in *url.Values, out *Con
 	} else {
 		out.DebugImage = ""
 	}
+	if values, ok := map[string][]string(*in)["volume"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.Volume, s); err != nil {
+			return err
+		}
+	} else {
+		out.Volume = ""
+	}
 	return nil
 }
 
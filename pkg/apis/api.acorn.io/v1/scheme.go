@@ -28,6 +28,7 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&BuilderPortOptions{},
 		&BuilderList{},
 		&ConfirmUpgrade{},
+		&JobRun{},
 		&AppPullImage{},
 		&Image{},
 		&ImageList{},
@@ -40,6 +41,9 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&LogOptions{},
 		&Volume{},
 		&VolumeList{},
+		&VolumeBackup{},
+		&VolumeBackupList{},
+		&VolumeBackupRestore{},
 		&Credential{},
 		&CredentialList{},
 		&ContainerReplica{},
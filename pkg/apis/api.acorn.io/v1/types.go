@@ -125,6 +125,9 @@ type LogMessage struct {
 	ContainerName string      `json:"containerName,omitempty"`
 	Time          metav1.Time `json:"time,omitempty"`
 	Error         string      `json:"error,omitempty"`
+	// JobRunResult is the Complete or Failed condition of the Job of a job run. It is only set on the message sent
+	// after the job run finished, the Error of that message is the reason a failed job run failed.
+	JobRunResult string `json:"jobRunResult,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -136,6 +139,7 @@ type LogOptions struct {
 	Follow           bool   `json:"follow,omitempty"`
 	ContainerReplica string `json:"containerReplica,omitempty"`
 	Since            string `json:"since,omitempty"`
+	JobRun           string `json:"jobRun,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -154,6 +158,18 @@ type ConfirmUpgrade struct {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+type JobRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// JobName is the name of the job in the app to run
+	JobName string `json:"jobName,omitempty"`
+	// Job is the name of the Job that was created for the run
+	Job string `json:"job,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 type ImageDetails struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
@@ -217,17 +233,43 @@ type VolumeSpec struct {
 }
 
 type VolumeStatus struct {
-	AppName      string        `json:"appName,omitempty"`
-	AppNamespace string        `json:"appNamespace,omitempty"`
-	VolumeName   string        `json:"volumeName,omitempty"`
-	Status       string        `json:"status,omitempty"`
-	Columns      VolumeColumns `json:"columns,omitempty"`
+	AppName              string        `json:"appName,omitempty"`
+	AppNamespace         string        `json:"appNamespace,omitempty"`
+	VolumeName           string        `json:"volumeName,omitempty"`
+	Status               string        `json:"status,omitempty"`
+	ContainerReplicaName string        `json:"containerReplicaName,omitempty"`
+	Columns              VolumeColumns `json:"columns,omitempty"`
 }
 
 type VolumeColumns struct {
 	AccessModes string `json:"accessModes,omitempty"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeBackup v1.VolumeBackupInstance
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeBackup `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeBackupRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Volume is the name of the volume the backup is restored to
+	Volume string `json:"volume,omitempty"`
+	// AppName is the app of the volume and Job is the name of the Job that restores the data
+	AppName string `json:"appName,omitempty"`
+	Job     string `json:"job,omitempty"`
+}
+
 // +k8s:conversion-gen:explicit-from=net/url.Values
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -237,6 +279,7 @@ type ContainerReplicaExecOptions struct {
 	Command    []string `json:"command,omitempty"`
 	TTY        bool     `json:"tty,omitempty"`
 	DebugImage string   `json:"debugImage,omitempty"`
+	Volume     string   `json:"volume,omitempty"`
 }
 
 const (
@@ -269,9 +312,10 @@ type Secret struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 
-	Type string            `json:"type,omitempty"`
-	Data map[string][]byte `json:"data,omitempty"`
-	Keys []string          `json:"keys,omitempty"`
+	Type      string            `json:"type,omitempty"`
+	Data      map[string][]byte `json:"data,omitempty"`
+	Keys      []string          `json:"keys,omitempty"`
+	Rotations []metav1.Time     `json:"rotations,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -316,6 +360,8 @@ type Config struct {
 	// pointer unless the default value (false, "") is not a valid configuration
 
 	IngressClassName             *string        `json:"ingressClassName" usage:"The ingress class name to assign to all created ingress resources (default '')"`
+	Gateway                      *string        `json:"gateway" name:"gateway" usage:"Publish HTTP endpoints as Gateway API HTTPRoutes attached to this Gateway instead of as Ingresses, in the form NAMESPACE/NAME or NAME for a Gateway in the acorn-system namespace (default '')"`
+	GatewayListener              *string        `json:"gatewayListener" name:"gateway-listener" usage:"The name of the listener of the Gateway that HTTPRoutes are attached to, they are attached to every listener that allows them if not set (default '')"`
 	ClusterDomains               []string       `json:"clusterDomains" name:"cluster-domain" usage:"The externally addressable cluster domain (default .on-acorn.io)"`
 	LetsEncrypt                  *string        `json:"letsEncrypt" name:"lets-encrypt" usage:"enabled|disabled|staging. If enabled, acorn generated endpoints will be secured using TLS certificate from Let's Encrypt. Staging uses Let's Encrypt's staging environment. (default disabled)"`
 	LetsEncryptEmail             string         `json:"letsEncryptEmail" name:"lets-encrypt-email" usage:"Required if --lets-encrypt=enabled. The email address to use for Let's Encrypt registration(default '')"`
@@ -332,6 +378,9 @@ type Config struct {
 	PublishBuilders              *bool          `json:"publishBuilders" name:"publish-builders" usage:"Publish the builders through ingress to so build traffic does not traverse the api-server"`
 	BuilderPerProject            *bool          `json:"builderPerProject" name:"builder-per-project" usage:"Create a dedicated builder per project"`
 	InternalRegistryPrefix       *string        `json:"internalRegistryPrefix" name:"internal-registry-prefix" usage:"The image prefix to use when pushing internal images (example ghcr.io/my-org/)"`
+	SecretProviders              []string       `json:"secretProviders" name:"secret-provider" split:"false" usage:"An external secret provider in the form NAME=URL,namespace=NAMESPACE, where URL is an http(s) endpoint returning JSON or a file:// directory. Only apps in the listed namespaces, or all with namespace=*, can bind its secrets as NAME://PATH#KEY"`
+	VolumeSnapshotClass          *string        `json:"volumeSnapshotClass" name:"volume-snapshot-class" usage:"The VolumeSnapshotClass used to back up volumes with CSI snapshots"`
+	VolumeBackupURL              *string        `json:"volumeBackupURL" name:"volume-backup-url" usage:"The S3 compatible location volume backups are uploaded to if no snapshot class is set (example https://s3.us-east-1.amazonaws.com/my-bucket/backups)"`
 }
 
 type EncryptionKey struct {
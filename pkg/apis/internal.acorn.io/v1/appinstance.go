@@ -17,6 +17,8 @@ var (
 	AppInstanceConditionSecrets    = "secrets"
 	AppInstanceConditionContainers = "containers"
 	AppInstanceConditionJobs       = "jobs"
+	AppInstanceConditionAcorns     = "acorns"
+	AppInstanceConditionRollout    = "rollout"
 	AppInstanceConditionReady      = "Ready"
 	AppInstanceConditionUpgrade    = "upgrade"
 )
@@ -55,24 +57,28 @@ const (
 )
 
 type AppInstanceSpec struct {
-	Labels              []ScopedLabel    `json:"labels,omitempty"`
-	Annotations         []ScopedLabel    `json:"annotations,omitempty"`
-	Image               string           `json:"image,omitempty"`
-	Stop                *bool            `json:"stop,omitempty"`
-	DevMode             *bool            `json:"devMode,omitempty"`
-	Profiles            []string         `json:"profiles,omitempty"`
-	Volumes             []VolumeBinding  `json:"volumes,omitempty"`
-	Secrets             []SecretBinding  `json:"secrets,omitempty"`
-	Environment         []NameValue      `json:"environment,omitempty"`
-	PublishMode         PublishMode      `json:"publishMode,omitempty"`
-	TargetNamespace     string           `json:"targetNamespace,omitempty"`
-	Links               []ServiceBinding `json:"services,omitempty"`
-	Ports               []PortBinding    `json:"ports,omitempty"`
-	DeployArgs          GenericMap       `json:"deployArgs,omitempty"`
-	Permissions         []Permissions    `json:"permissions,omitempty"`
-	AutoUpgrade         *bool            `json:"autoUpgrade,omitempty"`
-	NotifyUpgrade       *bool            `json:"notifyUpgrade,omitempty"`
-	AutoUpgradeInterval string           `json:"autoUpgradeInterval,omitempty"`
+	Labels              []ScopedLabel            `json:"labels,omitempty"`
+	Annotations         []ScopedLabel            `json:"annotations,omitempty"`
+	Image               string                   `json:"image,omitempty"`
+	Stop                *bool                    `json:"stop,omitempty"`
+	DevMode             *bool                    `json:"devMode,omitempty"`
+	Profiles            []string                 `json:"profiles,omitempty"`
+	Volumes             []VolumeBinding          `json:"volumes,omitempty"`
+	Secrets             []SecretBinding          `json:"secrets,omitempty"`
+	Environment         []NameValue              `json:"environment,omitempty"`
+	PublishMode         PublishMode              `json:"publishMode,omitempty"`
+	TargetNamespace     string                   `json:"targetNamespace,omitempty"`
+	Links               []ServiceBinding         `json:"services,omitempty"`
+	ExternalServices    []ExternalServiceBinding `json:"externalServices,omitempty"`
+	Ports               []PortBinding            `json:"ports,omitempty"`
+	DeployArgs          GenericMap               `json:"deployArgs,omitempty"`
+	Permissions         []Permissions            `json:"permissions,omitempty"`
+	Memory              ResourceMap              `json:"memory,omitempty"`
+	CPU                 ResourceMap              `json:"cpu,omitempty"`
+	ComputeClass        ComputeClassMap          `json:"computeClass,omitempty"`
+	AutoUpgrade         *bool                    `json:"autoUpgrade,omitempty"`
+	NotifyUpgrade       *bool                    `json:"notifyUpgrade,omitempty"`
+	AutoUpgradeInterval string                   `json:"autoUpgradeInterval,omitempty"`
 }
 
 func (in *AppInstanceSpec) GetAutoUpgrade() bool {
@@ -87,6 +93,16 @@ func (in *AppInstanceSpec) GetDevMode() bool {
 	return in.DevMode != nil && *in.DevMode
 }
 
+// GetVolumeBinding returns the binding of the volume and true if an existing volume is bound to it
+func (in *AppInstanceSpec) GetVolumeBinding(volume string) (VolumeBinding, bool) {
+	for _, v := range in.Volumes {
+		if v.Target == volume {
+			return v, v.Volume != ""
+		}
+	}
+	return VolumeBinding{}, false
+}
+
 func (in *AppInstanceSpec) GetProfiles() []string {
 	if in.GetDevMode() {
 		found := false
@@ -108,6 +124,13 @@ type ServiceBinding struct {
 	Service string `json:"service,omitempty"`
 }
 
+// ExternalServiceBinding overrides the address and port of a service declared in the services section of the app
+type ExternalServiceBinding struct {
+	Target  string `json:"target,omitempty"`
+	Address string `json:"address,omitempty"`
+	Port    int32  `json:"port,omitempty"`
+}
+
 type SecretBinding struct {
 	Secret string `json:"secret,omitempty"`
 	Target string `json:"target,omitempty"`
@@ -129,6 +152,18 @@ type ContainerStatus struct {
 	UpToDate     int32 `json:"upToDate,omitempty"`
 	RestartCount int32 `json:"restartCount,omitempty"`
 	Created      bool  `json:"created,omitempty"`
+
+	// CurrentReplicas is the number of replicas that exist and DesiredReplicas is the number that
+	// the container is being scaled to, which is chosen by the autoscaler when scale is a range
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+}
+
+type AcornStatus struct {
+	Ready   bool   `json:"ready,omitempty"`
+	Stopped bool   `json:"stopped,omitempty"`
+	Created bool   `json:"created,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 type JobStatus struct {
@@ -136,6 +171,19 @@ type JobStatus struct {
 	Failed  bool   `json:"failed,omitempty"`
 	Running bool   `json:"running,omitempty"`
 	Message string `json:"message,omitempty"`
+
+	// Skipped is true if the job does not run on the current app event
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Events is the status of the last run of the job for each app event it ran on
+	Events map[string]JobEventStatus `json:"events,omitempty"`
+}
+
+type JobEventStatus struct {
+	Succeed bool   `json:"succeed,omitempty"`
+	Failed  bool   `json:"failed,omitempty"`
+	Running bool   `json:"running,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 type AppColumns struct {
@@ -144,6 +192,7 @@ type AppColumns struct {
 	Message   string `json:"message,omitempty" column:"name=Message,jsonpath=.status.columns.message"`
 	Endpoints string `json:"endpoints,omitempty" column:"name=Endpoints,jsonpath=.status.columns.endpoints"`
 	Created   string `json:"created,omitempty" column:"name=Created,jsonpath=.metadata.creationTimestamp"`
+	Resources string `json:"resources,omitempty" column:"name=Resources,jsonpath=.status.columns.resources"`
 }
 
 type AppInstanceStatus struct {
@@ -151,6 +200,7 @@ type AppInstanceStatus struct {
 	Columns                AppColumns                 `json:"columns,omitempty"`
 	ContainerStatus        map[string]ContainerStatus `json:"containerStatus,omitempty"`
 	JobsStatus             map[string]JobStatus       `json:"jobsStatus,omitempty"`
+	AcornStatus            map[string]AcornStatus     `json:"acornStatus,omitempty"`
 	Ready                  bool                       `json:"ready,omitempty"`
 	Stopped                bool                       `json:"stopped,omitempty"`
 	Namespace              string                     `json:"namespace,omitempty"`
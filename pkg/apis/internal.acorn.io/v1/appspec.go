@@ -2,9 +2,14 @@ package v1
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
+	"golang.org/x/exp/slices"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -13,10 +18,14 @@ const (
 	AccessModeReadWriteMany AccessMode = "readWriteMany"
 	AccessModeReadWriteOnce AccessMode = "readWriteOnce"
 	AccessModeReadOnlyMany  AccessMode = "readOnlyMany"
+
+	VolumeMediumMemory VolumeMedium = "memory"
 )
 
 type AccessMode string
 
+type VolumeMedium string
+
 const (
 	ChangeTypeRedeploy = "redeploy"
 	ChangeTypeNoAction = "noAction"
@@ -52,11 +61,33 @@ func (in Build) BaseBuild() Build {
 type Protocol string
 
 var (
-	ProtocolTCP  = Protocol("tcp")
-	ProtocolUDP  = Protocol("udp")
-	ProtocolHTTP = Protocol("http")
+	ProtocolTCP   = Protocol("tcp")
+	ProtocolUDP   = Protocol("udp")
+	ProtocolHTTP  = Protocol("http")
+	ProtocolHTTP2 = Protocol("http2")
+	ProtocolGRPC  = Protocol("grpc")
 )
 
+// IsHTTP returns true for the protocols that are published with an ingress
+func (in Protocol) IsHTTP() bool {
+	return in == ProtocolHTTP || in == ProtocolHTTP2 || in == ProtocolGRPC
+}
+
+// PublishProtocol returns the protocol an ingress endpoint of this protocol is reached with
+func (in Protocol) PublishProtocol(tls bool) PublishProtocol {
+	switch {
+	case in == ProtocolGRPC && tls:
+		return PublishProtocolGRPCS
+	case in == ProtocolGRPC:
+		return PublishProtocolGRPC
+	case in.IsHTTP() && tls:
+		return PublishProtocolHTTPS
+	case in.IsHTTP():
+		return PublishProtocolHTTP
+	}
+	return PublishProtocol(in)
+}
+
 type PublishProtocol string
 
 var (
@@ -64,6 +95,8 @@ var (
 	PublishProtocolUDP   = PublishProtocol("udp")
 	PublishProtocolHTTP  = PublishProtocol("http")
 	PublishProtocolHTTPS = PublishProtocol("https")
+	PublishProtocolGRPC  = PublishProtocol("grpc")
+	PublishProtocolGRPCS = PublishProtocol("grpcs")
 )
 
 type PortBinding struct {
@@ -150,10 +183,24 @@ func (in PortDef) Complete(serviceName string) PortDef {
 	return in
 }
 
+const (
+	// MaxFileSize is the largest content of a file. File content is stored in ConfigMaps or Secrets which are limited
+	// to 1MiB, including the 12 character key of each file.
+	MaxFileSize = 1024*1024 - 12
+	// MaxFilesSize is the largest total content of the files of an app. The content is kept in the Acornfile and,
+	// base64 encoded, in the status of the AppInstance, which has to stay below the 1.5MiB limit of etcd.
+	MaxFilesSize = 512 * 1024
+)
+
 type File struct {
-	Mode    string          `json:"mode,omitempty"`
-	Content string          `json:"content,omitempty"`
-	Secret  SecretReference `json:"secret,omitempty"`
+	Mode      string          `json:"mode,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	Secret    SecretReference `json:"secret,omitempty"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+	// Binary is set for content given as base64 or bytes
+	Binary bool `json:"binary,omitempty"`
+	// Interpolate is set for content given as template, the ${...} references in it are replaced
+	Interpolate bool `json:"interpolate,omitempty"`
 }
 
 type VolumeSecretMount struct {
@@ -197,6 +244,25 @@ type TCPProbe struct {
 	URL string `json:"url,omitempty"`
 }
 
+type GRPCProbe struct {
+	URL string `json:"url,omitempty"`
+	// Service is the name of the service in the gRPC health check request
+	Service string `json:"service,omitempty"`
+}
+
+// Port returns the port of the URL, which must be in the form grpc://HOST:PORT because gRPC has no default port
+func (in GRPCProbe) Port() (int32, error) {
+	u, err := url.Parse(in.URL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid grpc probe url [%s]: %w", in.URL, err)
+	}
+	port, err := strconv.ParseUint(u.Port(), 10, 16)
+	if u.Scheme != "grpc" || err != nil || port == 0 {
+		return 0, fmt.Errorf("invalid grpc probe url [%s], must be in the form grpc://HOST:PORT", in.URL)
+	}
+	return int32(port), nil
+}
+
 type HTTPProbe struct {
 	URL     string            `json:"url,omitempty"`
 	Headers map[string]string `json:"headers,omitempty"`
@@ -215,6 +281,7 @@ type Probe struct {
 	Exec                *ExecProbe `json:"exec,omitempty"`
 	HTTP                *HTTPProbe `json:"http,omitempty"`
 	TCP                 *TCPProbe  `json:"tcp,omitempty"`
+	GRPC                *GRPCProbe `json:"grpc,omitempty"`
 	InitialDelaySeconds int32      `json:"initialDelaySeconds,omitempty"`
 	TimeoutSeconds      int32      `json:"timeoutSeconds,omitempty"`
 	PeriodSeconds       int32      `json:"periodSeconds,omitempty"`
@@ -244,6 +311,9 @@ type Permissions struct {
 	ServiceName  string              `json:"serviceName,omitempty"`
 	Rules        []PolicyRule        `json:"rules,omitempty"`
 	ClusterRules []ClusterPolicyRule `json:"clusterRules,omitempty"`
+
+	// Escalations are the security context escalations, such as privileged, of the containers in the service
+	Escalations []string `json:"escalations,omitempty"`
 }
 
 func (in *Permissions) HasRules() bool {
@@ -253,6 +323,13 @@ func (in *Permissions) HasRules() bool {
 	return len(in.ClusterRules) > 0 || len(in.Rules) > 0
 }
 
+func (in *Permissions) HasEscalation(escalation string) bool {
+	if in == nil {
+		return false
+	}
+	return slices.Contains(in.Escalations, escalation)
+}
+
 func (in *Permissions) Get() Permissions {
 	if in == nil {
 		return Permissions{}
@@ -301,13 +378,33 @@ type Container struct {
 	Probes       Probes                 `json:"probes"` // Don't omitempty so that nil vs empty is recorded
 	Dependencies Dependencies           `json:"dependencies,omitempty"`
 	Permissions  *Permissions           `json:"permissions,omitempty"`
+	Memory       *ResourceRequirement   `json:"memory,omitempty"`
+	CPU          *ResourceRequirement   `json:"cpu,omitempty"`
+	Class        string                 `json:"class,omitempty"`
+	Security     *Security              `json:"security,omitempty"`
+	Lifecycle    *Lifecycle             `json:"lifecycle,omitempty"`
 
 	// Scale is only available on containers, not sidecars or jobs
 	Scale *int32 `json:"scale,omitempty"`
 
+	// Autoscale is set when scale is defined with a range of replicas instead of a fixed number
+	Autoscale *Autoscale `json:"autoscale,omitempty"`
+
+	// Availability is only available on containers, not sidecars or jobs
+	Availability *Availability `json:"availability,omitempty"`
+
+	// Rollout is only available on containers, not sidecars or jobs
+	Rollout *Rollout `json:"rollout,omitempty"`
+
+	// StopGracePeriod is only available on containers and jobs, not sidecars
+	StopGracePeriod string `json:"stopGracePeriod,omitempty"`
+
 	// Schedule is only available on jobs
 	Schedule string `json:"schedule,omitempty"`
 
+	// Events is only available on jobs
+	Events []string `json:"events,omitempty"`
+
 	// Init is only available on sidecars
 	Init bool `json:"init,omitempty"`
 
@@ -315,20 +412,167 @@ type Container struct {
 	Sidecars map[string]Container `json:"sidecars,omitempty"`
 }
 
+// Autoscale configures a horizontal pod autoscaler for a container. The utilization targets are a
+// percentage of the container's cpu or memory request.
+type Autoscale struct {
+	Min               *int32 `json:"min,omitempty"`
+	Max               int32  `json:"max,omitempty"`
+	CPUUtilization    *int32 `json:"cpuUtilization,omitempty"`
+	MemoryUtilization *int32 `json:"memoryUtilization,omitempty"`
+}
+
+const (
+	SpreadAcrossNode = "node"
+	SpreadAcrossZone = "zone"
+	SpreadAcrossNone = "none"
+)
+
+// Availability controls how many replicas of a scaled container must stay up during voluntary
+// disruptions, such as node drains, and how the replicas are spread over the cluster
+type Availability struct {
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	SpreadAcross string              `json:"spreadAcross,omitempty"`
+}
+
+const (
+	RolloutStrategyRolling  = "rolling"
+	RolloutStrategyRecreate = "recreate"
+)
+
+// Rollout controls how a new version of a container replaces the running replicas. ProgressDeadline is a
+// duration, such as "10m", after which a rollout that has not made progress is reported as failed.
+type Rollout struct {
+	Strategy         string              `json:"strategy,omitempty"`
+	MaxSurge         *intstr.IntOrString `json:"maxSurge,omitempty"`
+	MaxUnavailable   *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	ProgressDeadline string              `json:"progressDeadline,omitempty"`
+}
+
+const (
+	JobEventCreate = "create"
+	JobEventUpdate = "update"
+	JobEventDelete = "delete"
+	JobEventStop   = "stop"
+)
+
+// Lifecycle are the hooks run after a container starts and before it is stopped
+type Lifecycle struct {
+	PostStart *LifecycleHandler `json:"postStart,omitempty"`
+	PreStop   *LifecycleHandler `json:"preStop,omitempty"`
+}
+
+// LifecycleHandler is a command or an HTTP request, in the same forms as a probe
+type LifecycleHandler struct {
+	Exec *ExecProbe `json:"exec,omitempty"`
+	HTTP *HTTPProbe `json:"http,omitempty"`
+}
+
+const (
+	EscalationPrivileged       = "privileged"
+	EscalationHostNetwork      = "hostNetwork"
+	EscalationHostPID          = "hostPID"
+	EscalationHostIPC          = "hostIPC"
+	EscalationCapabilityPrefix = "capability:"
+)
+
+// Security is the security context of a container. Privileged, added capabilities and host namespaces
+// are escalations that must be approved, like permissions, before they are applied.
+type Security struct {
+	RunAsUser              *int64        `json:"runAsUser,omitempty"`
+	RunAsGroup             *int64        `json:"runAsGroup,omitempty"`
+	RunAsNonRoot           *bool         `json:"runAsNonRoot,omitempty"`
+	ReadOnlyRootFilesystem bool          `json:"readOnlyRootFilesystem,omitempty"`
+	Privileged             bool          `json:"privileged,omitempty"`
+	Capabilities           *Capabilities `json:"capabilities,omitempty"`
+	HostNetwork            bool          `json:"hostNetwork,omitempty"`
+	HostPID                bool          `json:"hostPID,omitempty"`
+	HostIPC                bool          `json:"hostIPC,omitempty"`
+}
+
+type Capabilities struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop,omitempty"`
+}
+
+// Escalations returns the escalations requested by the security context, added capabilities are
+// returned as capability:NAME
+func (in *Security) Escalations() (result []string) {
+	if in == nil {
+		return nil
+	}
+	if in.Privileged {
+		result = append(result, EscalationPrivileged)
+	}
+	if in.HostNetwork {
+		result = append(result, EscalationHostNetwork)
+	}
+	if in.HostPID {
+		result = append(result, EscalationHostPID)
+	}
+	if in.HostIPC {
+		result = append(result, EscalationHostIPC)
+	}
+	if in.Capabilities != nil {
+		for _, capability := range in.Capabilities.Add {
+			result = append(result, EscalationCapabilityPrefix+capability)
+		}
+	}
+	return
+}
+
+// ResourceRequirement is the request and limit of a single compute resource. When
+// defined as a single value the request and limit are both set to that value.
+type ResourceRequirement struct {
+	Request string `json:"request,omitempty"`
+	Limit   string `json:"limit,omitempty"`
+}
+
 type Image struct {
 	Image string `json:"image,omitempty"`
 	Build *Build `json:"build,omitempty"`
 }
 
+type PortBindings []PortBinding
+
+type AcornBuild struct {
+	Context   string     `json:"context,omitempty"`
+	Acornfile string     `json:"acornfile,omitempty"`
+	BuildArgs GenericMap `json:"buildArgs,omitempty"`
+}
+
+type Acorn struct {
+	Labels      ScopedLabels     `json:"labels,omitempty"`
+	Annotations ScopedLabels     `json:"annotations,omitempty"`
+	Image       string           `json:"image,omitempty"`
+	Build       *AcornBuild      `json:"build,omitempty"`
+	Profiles    []string         `json:"profiles,omitempty"`
+	DeployArgs  GenericMap       `json:"deployArgs,omitempty"`
+	Ports       PortBindings     `json:"ports,omitempty"`
+	Volumes     []VolumeBinding  `json:"volumes,omitempty"`
+	Secrets     []SecretBinding  `json:"secrets,omitempty"`
+	Links       []ServiceBinding `json:"links,omitempty"`
+	Environment NameValues       `json:"environment,omitempty"`
+}
+
 type AppSpec struct {
 	Labels      map[string]string        `json:"labels,omitempty"`
 	Annotations map[string]string        `json:"annotations,omitempty"`
 	Containers  map[string]Container     `json:"containers,omitempty"`
 	Jobs        map[string]Container     `json:"jobs,omitempty"`
 	Images      map[string]Image         `json:"images,omitempty"`
+	Acorns      map[string]Acorn         `json:"acorns,omitempty"`
 	Volumes     map[string]VolumeRequest `json:"volumes,omitempty"`
 	Secrets     map[string]Secret        `json:"secrets,omitempty"`
 	Routers     map[string]Router        `json:"routers,omitempty"`
+	Services    map[string]Service       `json:"services,omitempty"`
+}
+
+// Service is a dependency that does not run in acorn, such as a managed database. It is addressed in the app by
+// its name like any other service.
+type Service struct {
+	// Address is the hostname or IP address of the service
+	Address string `json:"address,omitempty"`
+	Ports   Ports  `json:"ports,omitempty"`
 }
 
 type Route struct {
@@ -336,6 +580,69 @@ type Route struct {
 	TargetServiceName string   `json:"targetServiceName,omitempty"`
 	TargetPort        int      `json:"targetPort,omitempty"`
 	PathType          PathType `json:"pathType,omitempty"`
+	// Targets split the traffic of the route between services by weight, they are used instead of TargetServiceName
+	Targets []WeightedTarget `json:"targets,omitempty"`
+	// StickyCookie is the name of the cookie that keeps a client on the target it was first sent to
+	StickyCookie string `json:"stickyCookie,omitempty"`
+	// Match limits the route to requests with these methods and headers, other requests fall through to the next
+	// route with the same path
+	Match *RouteMatch `json:"match,omitempty"`
+	// StripPrefix removes the path of the route from the request before it is proxied
+	StripPrefix bool `json:"stripPrefix,omitempty"`
+	// Rewrite replaces the path of the route in the request before it is proxied
+	Rewrite string `json:"rewrite,omitempty"`
+	// Redirect responds with a redirect instead of proxying to a target
+	Redirect *RouteRedirect `json:"redirect,omitempty"`
+}
+
+type RouteMatch struct {
+	Methods []string `json:"methods,omitempty"`
+	// Headers are the exact values of request headers
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type RouteRedirect struct {
+	URL string `json:"url,omitempty"`
+	// Code is the HTTP status code of the redirect, it defaults to 302
+	Code int `json:"code,omitempty"`
+}
+
+// RequiresRouter returns true if the route can not be sent to its target by an ingress directly, because it uses
+// features that only the router implements
+func (in Route) RequiresRouter() bool {
+	return len(in.Targets) > 0 || in.StickyCookie != "" || in.Match != nil || in.StripPrefix || in.Rewrite != "" ||
+		in.Redirect != nil
+}
+
+type WeightedTarget struct {
+	TargetServiceName string `json:"targetServiceName,omitempty"`
+	TargetPort        int    `json:"targetPort,omitempty"`
+	// Weight is the share of the traffic of the route relative to the other targets. It defaults to 1, a target with a
+	// weight of 0 gets no traffic.
+	Weight *int `json:"weight,omitempty"`
+}
+
+func (in WeightedTarget) GetWeight() int {
+	if in.Weight == nil {
+		return 1
+	}
+	return *in.Weight
+}
+
+// WeightedTargets returns the targets of the route, a route with only TargetServiceName has a single target
+func (in Route) WeightedTargets() []WeightedTarget {
+	if len(in.Targets) > 0 {
+		return in.Targets
+	}
+	if in.TargetServiceName == "" {
+		return nil
+	}
+	return []WeightedTarget{
+		{
+			TargetServiceName: in.TargetServiceName,
+			TargetPort:        in.TargetPort,
+		},
+	}
 }
 
 type Routes []Route
@@ -352,6 +659,14 @@ type Secret struct {
 	Type        string            `json:"type,omitempty"`
 	Params      GenericMap        `json:"params,omitempty"`
 	Data        map[string]string `json:"data,omitempty"`
+	Rotation    *SecretRotation   `json:"rotation,omitempty"`
+}
+
+type SecretRotation struct {
+	// Every is how often new values are generated
+	Every string `json:"every,omitempty"`
+	// Overlap is how long the previous values are kept after a rotation
+	Overlap string `json:"overlap,omitempty"`
 }
 
 type AccessModes []AccessMode
@@ -362,4 +677,42 @@ type VolumeRequest struct {
 	Class       string            `json:"class,omitempty"`
 	Size        Quantity          `json:"size,omitempty"`
 	AccessModes AccessModes       `json:"accessModes,omitempty"`
+	Seed        *VolumeSeed       `json:"seed,omitempty"`
+	// Medium is only valid for ephemeral volumes, it can be "memory" to use a tmpfs
+	Medium VolumeMedium `json:"medium,omitempty"`
+	// PerReplica gives each replica of the containers mounting the volume its own volume, the containers are run as
+	// StatefulSets
+	PerReplica bool `json:"perReplica,omitempty"`
+}
+
+// MemoryVolumeSize returns the total size of the memory backed volumes mounted by the container, not including the
+// volumes of its sidecars. Volumes bound to an existing volume are not memory backed.
+func (in *AppSpec) MemoryVolumeSize(spec *AppInstanceSpec, container Container) (result resource.Quantity) {
+	seen := map[string]bool{}
+	for _, dir := range container.Dirs {
+		volume := dir.Volume
+		if volume == "" || seen[volume] {
+			continue
+		}
+		seen[volume] = true
+
+		vr, ok := in.Volumes[volume]
+		if !ok || !strings.EqualFold(vr.Class, VolumeRequestTypeEphemeral) || vr.Medium != VolumeMediumMemory || vr.Size == "" {
+			continue
+		}
+		if _, bind := spec.GetVolumeBinding(volume); bind {
+			continue
+		}
+		result.Add(*MustParseResourceQuantity(vr.Size))
+	}
+	return
+}
+
+// VolumeSeed is the content copied into a volume the first time it is mounted
+type VolumeSeed struct {
+	// ContextDir is a directory of the build context, it is built into Image
+	ContextDir string `json:"contextDir,omitempty"`
+	Image      string `json:"image,omitempty"`
+	// Path is the directory in Image that is copied
+	Path string `json:"path,omitempty"`
 }
@@ -20,11 +20,22 @@ type ImageBuilderSpec struct {
 	Build *Build `json:"build,omitempty"`
 }
 
+type AcornBuilderSpec struct {
+	Image string      `json:"image,omitempty"`
+	Build *AcornBuild `json:"build,omitempty"`
+}
+
+type VolumeBuilderSpec struct {
+	Seed *VolumeSeed `json:"seed,omitempty"`
+}
+
 type BuilderSpec struct {
 	Platforms  []Platform                           `json:"platforms,omitempty"`
 	Containers map[string]ContainerImageBuilderSpec `json:"containers,omitempty"`
 	Jobs       map[string]ContainerImageBuilderSpec `json:"jobs,omitempty"`
 	Images     map[string]ImageBuilderSpec          `json:"images,omitempty"`
+	Acorns     map[string]AcornBuilderSpec          `json:"acorns,omitempty"`
+	Volumes    map[string]VolumeBuilderSpec         `json:"volumes,omitempty"`
 }
 
 type ParamSpec struct {
@@ -61,6 +72,8 @@ type AcornImageBuildInstanceSpec struct {
 	Args        GenericMap `json:"args,omitempty"`
 	Profiles    []string   `json:"profiles,omitempty"`
 	VCS         VCS        `json:"vcs,omitempty"`
+	// Acorns are the images of nested acorns that were built by the client before this build started
+	Acorns map[string]ImageData `json:"acorns,omitempty"`
 }
 
 type AcornImageBuildInstanceStatus struct {
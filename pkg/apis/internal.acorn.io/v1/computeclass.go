@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComputeClassInstance is a cluster scoped set of scheduling and resource rules that containers can reference by name
+type ComputeClassInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Description string `json:"description,omitempty"`
+	// Default marks this class as the one to use for containers that don't reference a class
+	Default           bool                 `json:"default,omitempty"`
+	Memory            ComputeClassResource `json:"memory,omitempty"`
+	CPU               ComputeClassResource `json:"cpu,omitempty"`
+	Affinity          *corev1.Affinity     `json:"affinity,omitempty"`
+	Tolerations       []corev1.Toleration  `json:"tolerations,omitempty"`
+	PriorityClassName string               `json:"priorityClassName,omitempty"`
+}
+
+// NamespaceScoped is false because compute classes are defined for the whole cluster
+func (in *ComputeClassInstance) NamespaceScoped() bool {
+	return false
+}
+
+// ComputeClassResource is the default and allowed range of a single compute resource. All values are
+// quantities and an empty value means there is no default or bound.
+type ComputeClassResource struct {
+	Default string `json:"default,omitempty"`
+	Min     string `json:"min,omitempty"`
+	Max     string `json:"max,omitempty"`
+}
+
+// WithDefault returns req unless it has neither a request nor a limit, in which case both are set to the default
+func (in ComputeClassResource) WithDefault(req ResourceRequirement) ResourceRequirement {
+	if req.Request == "" && req.Limit == "" {
+		req.Request = in.Default
+		req.Limit = in.Default
+	}
+	return req
+}
+
+// Check returns an error if the request or limit in req is outside the min and max of the class
+func (in ComputeClassResource) Check(req ResourceRequirement) error {
+	for _, value := range []string{req.Request, req.Limit} {
+		if value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return err
+		}
+		if in.Min != "" {
+			if min, err := resource.ParseQuantity(in.Min); err != nil {
+				return err
+			} else if q.Cmp(min) < 0 {
+				return fmt.Errorf("%s is less than the minimum %s", value, in.Min)
+			}
+		}
+		if in.Max != "" {
+			if max, err := resource.ParseQuantity(in.Max); err != nil {
+				return err
+			} else if q.Cmp(max) > 0 {
+				return fmt.Errorf("%s is greater than the maximum %s", value, in.Max)
+			}
+		}
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ComputeClassInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComputeClassInstance `json:"items"`
+}
+
+// Default returns the class marked as the default. If more than one is marked the first by name is used
+// so the choice is stable.
+func (in *ComputeClassInstanceList) Default() *ComputeClassInstance {
+	var result *ComputeClassInstance
+	for i, class := range in.Items {
+		if class.Default && (result == nil || class.Name < result.Name) {
+			result = &in.Items[i]
+		}
+	}
+	return result
+}
+
+// ComputeClassMap is a set of compute class names keyed by container name. The empty key applies
+// to all containers that do not have a specific entry.
+type ComputeClassMap map[string]string
+
+// Get returns the class for the given container name, falling back to the class for all containers
+func (in ComputeClassMap) Get(containerName string) string {
+	if class, ok := in[containerName]; ok {
+		return class
+	}
+	return in[""]
+}
+
+// ParseComputeClasses parses values in the format [containername=]class (ex: web=large)
+func ParseComputeClasses(args []string) (ComputeClassMap, error) {
+	result := ComputeClassMap{}
+	for _, arg := range args {
+		containerName, class, ok := strings.Cut(arg, "=")
+		if !ok {
+			class = containerName
+			containerName = ""
+		}
+		class = strings.TrimSpace(class)
+		if class == "" {
+			return nil, fmt.Errorf("invalid compute class [%s] must not have zero length value", arg)
+		}
+		result[strings.TrimSpace(containerName)] = class
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeClassResourceCheck(t *testing.T) {
+	class := ComputeClassResource{
+		Min: "128Mi",
+		Max: "1Gi",
+	}
+
+	assert.NoError(t, class.Check(ResourceRequirement{}))
+	assert.NoError(t, class.Check(ResourceRequirement{Request: "128Mi", Limit: "1Gi"}))
+	assert.EqualError(t, class.Check(ResourceRequirement{Request: "64Mi", Limit: "512Mi"}), "64Mi is less than the minimum 128Mi")
+	assert.EqualError(t, class.Check(ResourceRequirement{Request: "512Mi", Limit: "2Gi"}), "2Gi is greater than the maximum 1Gi")
+}
+
+func TestComputeClassResourceWithDefault(t *testing.T) {
+	class := ComputeClassResource{
+		Default: "256Mi",
+	}
+
+	assert.Equal(t, ResourceRequirement{Request: "256Mi", Limit: "256Mi"}, class.WithDefault(ResourceRequirement{}))
+	assert.Equal(t, ResourceRequirement{Request: "64Mi"}, class.WithDefault(ResourceRequirement{Request: "64Mi"}))
+}
+
+func TestParseComputeClasses(t *testing.T) {
+	classes, err := ParseComputeClasses([]string{"large", "web=small"})
+	assert.NoError(t, err)
+	assert.Equal(t, "small", classes.Get("web"))
+	assert.Equal(t, "large", classes.Get("worker"))
+
+	_, err = ParseComputeClasses([]string{"web="})
+	assert.Error(t, err)
+}
+
+func TestMemoryVolumeSizeCheck(t *testing.T) {
+	appSpec := &AppSpec{
+		Volumes: map[string]VolumeRequest{
+			"shm": {
+				Class:  VolumeRequestTypeEphemeral,
+				Medium: VolumeMediumMemory,
+				Size:   "512Mi",
+			},
+			"data": {
+				Size: "10G",
+			},
+		},
+	}
+	container := Container{
+		Dirs: map[string]VolumeMount{
+			"/dev/shm":  {Volume: "shm"},
+			"/dev/shm2": {Volume: "shm"},
+			"/data":     {Volume: "data"},
+		},
+	}
+	class := ComputeClassResource{
+		Max: "1Gi",
+	}
+
+	size := appSpec.MemoryVolumeSize(&AppInstanceSpec{}, container)
+	assert.Equal(t, "512Mi", size.String())
+	req, err := ResourceRequirement{Request: "512Mi", Limit: "512Mi"}.Add(size)
+	assert.NoError(t, err)
+	assert.NoError(t, class.Check(req))
+	req, err = ResourceRequirement{Request: "512Mi", Limit: "768Mi"}.Add(size)
+	assert.NoError(t, err)
+	assert.EqualError(t, class.Check(req), "1280Mi is greater than the maximum 1Gi")
+	_, err = ResourceRequirement{Request: "lots"}.Add(size)
+	assert.ErrorContains(t, err, "parsing [lots]")
+
+	size = appSpec.MemoryVolumeSize(&AppInstanceSpec{Volumes: []VolumeBinding{{Volume: "existing", Target: "shm"}}}, container)
+	assert.True(t, size.IsZero())
+}
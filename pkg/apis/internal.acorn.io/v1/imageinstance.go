@@ -17,6 +17,9 @@ type ImagesData struct {
 	Containers map[string]ContainerData `json:"containers,omitempty"`
 	Jobs       map[string]ContainerData `json:"jobs,omitempty"`
 	Images     map[string]ImageData     `json:"images,omitempty"`
+	Acorns     map[string]ImageData     `json:"acorns,omitempty"`
+	// Volumes are the images that seed volumes
+	Volumes map[string]ImageData `json:"volumes,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
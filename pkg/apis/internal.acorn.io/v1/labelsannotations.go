@@ -11,6 +11,7 @@ const (
 	LabelTypeJob       = "job"
 	LabelTypeVolume    = "volume"
 	LabelTypeSecret    = "secret"
+	LabelTypeAcorn     = "acorn"
 	LabelTypeMeta      = "metadata"
 )
 
@@ -25,6 +26,8 @@ var canonicalTypes = map[string]string{
 	"volumes":    LabelTypeVolume,
 	"secret":     LabelTypeSecret,
 	"secrets":    LabelTypeSecret,
+	"acorn":      LabelTypeAcorn,
+	"acorns":     LabelTypeAcorn,
 	"metadata":   LabelTypeMeta,
 	"metadatas":  LabelTypeMeta,
 }
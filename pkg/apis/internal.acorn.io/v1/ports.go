@@ -2,6 +2,7 @@ package v1
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -19,6 +20,10 @@ func validProto(p string) (Protocol, bool) {
 	case ProtocolUDP:
 		fallthrough
 	case ProtocolHTTP:
+		fallthrough
+	case ProtocolHTTP2:
+		fallthrough
+	case ProtocolGRPC:
 		return ret, true
 	case "":
 		return ret, true
@@ -239,9 +244,53 @@ func ParseLinks(args []string) (result []ServiceBinding, _ error) {
 	return
 }
 
+// ParseExternalServices parses bindings in the form name=address[:port]
+func ParseExternalServices(args []string) (result []ExternalServiceBinding, _ error) {
+	for _, arg := range args {
+		name, address, _ := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		address = strings.TrimSpace(address)
+		if name == "" || address == "" {
+			return nil, fmt.Errorf("invalid service binding [%s] must be in the form name=address[:port]", arg)
+		}
+		address, port, err := parseAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service binding [%s]: %w", arg, err)
+		}
+		result = append(result, ExternalServiceBinding{
+			Target:  name,
+			Address: address,
+			Port:    port,
+		})
+	}
+	return
+}
+
+// parseAddress splits an optional port from a hostname or IP address
+func parseAddress(address string) (string, int32, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		// No port, which is fine
+		return strings.Trim(address, "[]"), 0, nil
+	}
+	portNum, err := strconv.ParseInt(port, 10, 32)
+	if err != nil || portNum <= 0 || portNum > 65535 {
+		return "", 0, fmt.Errorf("invalid port [%s]", port)
+	}
+	return host, int32(portNum), nil
+}
+
 func ParseSecrets(args []string) (result []SecretBinding, _ error) {
 	for _, arg := range args {
 		existing, secName, ok := strings.Cut(arg, ":")
+		if i := strings.Index(arg, "://"); i >= 0 {
+			// A reference to an external provider has a colon in it, the target follows the last one
+			j := strings.LastIndex(arg, ":")
+			if j <= i {
+				return nil, fmt.Errorf("invalid secret binding [%s], the secret name must be set for an external secret", arg)
+			}
+			existing, secName, ok = arg[:j], arg[j+1:], true
+		}
 		if !ok {
 			secName = existing
 		}
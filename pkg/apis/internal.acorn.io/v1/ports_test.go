@@ -51,3 +51,42 @@ func TestParseVolumes(t *testing.T) {
 		Class:  "aclass",
 	}, vs[1])
 }
+
+func TestParseExternalServices(t *testing.T) {
+	bindings, err := ParseExternalServices([]string{
+		"db=db.example.com:5432",
+		"cache=10.0.0.5",
+		"ipv6=[fd00::1]:6379",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalServiceBinding{
+		{Target: "db", Address: "db.example.com", Port: 5432},
+		{Target: "cache", Address: "10.0.0.5"},
+		{Target: "ipv6", Address: "fd00::1", Port: 6379},
+	}, bindings)
+
+	_, err = ParseExternalServices([]string{"db"})
+	assert.Error(t, err)
+
+	_, err = ParseExternalServices([]string{"db=db.example.com:http"})
+	assert.Error(t, err)
+}
+
+func TestParseSecrets(t *testing.T) {
+	bindings, err := ParseSecrets([]string{
+		"existing:target",
+		"same",
+		"vault://db/creds#password:db-pass",
+		"local://app:api",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []SecretBinding{
+		{Secret: "existing", Target: "target"},
+		{Secret: "same", Target: "same"},
+		{Secret: "vault://db/creds#password", Target: "db-pass"},
+		{Secret: "local://app", Target: "api"},
+	}, bindings)
+
+	_, err = ParseSecrets([]string{"vault://db/creds"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceMap is a set of resource overrides keyed by container name. The empty key applies
+// to all containers that do not have a specific entry.
+type ResourceMap map[string]ResourceRequirement
+
+// Get returns the override for the given container name, falling back to the override for all containers
+func (in ResourceMap) Get(containerName string) (ResourceRequirement, bool) {
+	if req, ok := in[containerName]; ok {
+		return req, true
+	}
+	req, ok := in[""]
+	return req, ok
+}
+
+// Effective returns the override for the container if there is one, otherwise the requirement defined in the Acornfile
+func (in ResourceMap) Effective(containerName string, defined *ResourceRequirement) ResourceRequirement {
+	if override, ok := in.Get(containerName); ok {
+		return override
+	}
+	if defined != nil {
+		return *defined
+	}
+	return ResourceRequirement{}
+}
+
+// Add returns the requirement with size added to the request and the limit. An unset request or limit stays unset.
+func (in ResourceRequirement) Add(size resource.Quantity) (ResourceRequirement, error) {
+	for _, value := range []*string{&in.Request, &in.Limit} {
+		if *value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(*value)
+		if err != nil {
+			return in, fmt.Errorf("parsing [%s]: %w", *value, err)
+		}
+		q.Add(size)
+		*value = q.String()
+	}
+	return in, nil
+}
+
+// ParseResources parses values in the format [containername=]quantity (ex: web=512Mi)
+func ParseResources(args []string) (ResourceMap, error) {
+	result := ResourceMap{}
+	for _, arg := range args {
+		containerName, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			value = containerName
+			containerName = ""
+		}
+		containerName = strings.TrimSpace(containerName)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("invalid resource [%s] must not have zero length value", arg)
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("parsing [%s]: %w", arg, err)
+		}
+		result[containerName] = ResourceRequirement{
+			Request: value,
+			Limit:   value,
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
@@ -9,6 +9,10 @@ const (
 	SecretTypeTemplate  corev1.SecretType = "secrets.acorn.io/template"
 	SecretTypeBasic     corev1.SecretType = "secrets.acorn.io/basic"
 	SecretTypeToken     corev1.SecretType = "secrets.acorn.io/token"
+	SecretTypeTLS       corev1.SecretType = "secrets.acorn.io/tls"
+	SecretTypeRSA       corev1.SecretType = "secrets.acorn.io/rsa"
+	SecretTypeED25519   corev1.SecretType = "secrets.acorn.io/ed25519"
+	SecretTypeSSH       corev1.SecretType = "secrets.acorn.io/ssh"
 )
 
 var (
@@ -18,5 +22,9 @@ var (
 		SecretTypeTemplate:  true,
 		SecretTypeBasic:     true,
 		SecretTypeToken:     true,
+		SecretTypeTLS:       true,
+		SecretTypeRSA:       true,
+		SecretTypeED25519:   true,
+		SecretTypeSSH:       true,
 	}
 )
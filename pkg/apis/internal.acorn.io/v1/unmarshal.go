@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -26,9 +27,15 @@ var (
 )
 
 type routeTarget struct {
-	PathType          PathType `json:"pathType,omitempty"`
-	TargetPort        int      `json:"targetPort,omitempty"`
-	TargetServiceName string   `json:"targetServiceName,omitempty"`
+	PathType          PathType         `json:"pathType,omitempty"`
+	TargetPort        int              `json:"targetPort,omitempty"`
+	TargetServiceName string           `json:"targetServiceName,omitempty"`
+	Targets           []WeightedTarget `json:"targets,omitempty"`
+	StickyCookie      string           `json:"stickyCookie,omitempty"`
+	Match             *RouteMatch      `json:"match,omitempty"`
+	StripPrefix       bool             `json:"stripPrefix,omitempty"`
+	Rewrite           string           `json:"rewrite,omitempty"`
+	Redirect          *RouteRedirect   `json:"redirect,omitempty"`
 }
 
 func (in *routeTarget) UnmarshalJSON(data []byte) error {
@@ -56,6 +63,21 @@ func (in *routeTarget) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *RouteRedirect) UnmarshalJSON(data []byte) error {
+	if !isString(data) {
+		type routeRedirect RouteRedirect
+		return json.Unmarshal(data, (*routeRedirect)(in))
+	}
+
+	s, err := parseString(data)
+	if err != nil {
+		return err
+	}
+	in.URL = s
+	in.Code = http.StatusFound
+	return nil
+}
+
 func (in *Routes) UnmarshalJSON(data []byte) error {
 	if !isObject(data) {
 		type routesType Routes
@@ -73,6 +95,12 @@ func (in *Routes) UnmarshalJSON(data []byte) error {
 			TargetServiceName: v.TargetServiceName,
 			TargetPort:        v.TargetPort,
 			PathType:          v.PathType,
+			Targets:           v.Targets,
+			StickyCookie:      v.StickyCookie,
+			Match:             v.Match,
+			StripPrefix:       v.StripPrefix,
+			Rewrite:           v.Rewrite,
+			Redirect:          v.Redirect,
 		})
 	}
 	sort.Slice(routes, func(i, j int) bool {
@@ -178,6 +206,31 @@ func (in *ServiceBinding) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *Service) UnmarshalJSON(data []byte) error {
+	if !isString(data) {
+		type service Service
+		return json.Unmarshal(data, (*service)(in))
+	}
+
+	s, err := parseString(data)
+	if err != nil {
+		return err
+	}
+	address, port, err := parseAddress(s)
+	if err != nil {
+		return err
+	}
+
+	in.Address = address
+	if port != 0 {
+		in.Ports = Ports{{
+			Port:       port,
+			TargetPort: port,
+		}}
+	}
+	return nil
+}
+
 func (in *SecretBinding) UnmarshalJSON(data []byte) error {
 	if !isString(data) {
 		type secretBinding SecretBinding
@@ -262,7 +315,9 @@ func impliedVolumesForContainer(app *AppSpec, containerName, sideCarName string,
 					// ignore error
 					continue
 				}
-				if existingSize.Cmp(vSize) < 0 {
+				// The size of a memory backed volume is a limit of the memory it uses, so it is not raised to the
+				// default size of a volume reference
+				if existingSize.Cmp(vSize) < 0 && existing.Medium != VolumeMediumMemory {
 					existing.Size = v.Size
 				}
 				for _, accessMode := range v.AccessModes {
@@ -331,6 +386,16 @@ func checkForDuplicateNames(in *AppSpec) error {
 			return err
 		}
 	}
+	for name := range in.Acorns {
+		if err := addName(names, name, "acorn"); err != nil {
+			return err
+		}
+	}
+	for name := range in.Services {
+		if err := addName(names, name, "service"); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -497,9 +562,27 @@ func adjustBuildForContextDirs(c Container) *Build {
 func (in *Container) UnmarshalJSON(data []byte) error {
 	var c Container
 	type container Container
-	if err := json.Unmarshal(data, (*container)(&c)); err != nil {
+	// scale is either the number of replicas or, as an object, the range the container is autoscaled in. The outer
+	// field hides the one of the embedded container so it is only decoded once its form is known.
+	wrapper := struct {
+		*container
+		Scale json.RawMessage `json:"scale,omitempty"`
+	}{
+		container: (*container)(&c),
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
 		return err
 	}
+	if isObject(wrapper.Scale) {
+		c.Autoscale = &Autoscale{}
+		if err := json.Unmarshal(wrapper.Scale, c.Autoscale); err != nil {
+			return err
+		}
+	} else if len(wrapper.Scale) > 0 {
+		if err := json.Unmarshal(wrapper.Scale, &c.Scale); err != nil {
+			return err
+		}
+	}
 
 	var alias containerAliases
 	if err := json.Unmarshal(data, &alias); err != nil {
@@ -662,6 +745,58 @@ func (in *Ports) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *PortBinding) UnmarshalJSON(data []byte) error {
+	if isString(data) {
+		s, err := parseString(data)
+		if err != nil {
+			return err
+		}
+		bindings, err := ParsePortBindings(false, []string{s})
+		if err != nil {
+			return err
+		}
+		*in = bindings[0]
+		return nil
+	} else if !isObject(data) {
+		var num int32
+		if err := json.Unmarshal(data, &num); err != nil {
+			return err
+		}
+		in.Expose = true
+		in.TargetPort = num
+		in.Port = num
+		return nil
+	}
+
+	type portBinding PortBinding
+	return json.Unmarshal(data, (*portBinding)(in))
+}
+
+func (in *PortBindings) UnmarshalJSON(data []byte) error {
+	if isObject(data) {
+		bindings := map[string]PortBindings{}
+		if err := json.Unmarshal(data, &bindings); err != nil {
+			return err
+		}
+		*in = append(*in, bindings["expose"]...)
+		for _, binding := range bindings["publish"] {
+			binding.Expose = false
+			binding.Publish = true
+			*in = append(*in, binding)
+		}
+		return nil
+	} else if isArray(data) {
+		return json.Unmarshal(data, (*[]PortBinding)(in))
+	}
+
+	var binding PortBinding
+	if err := json.Unmarshal(data, &binding); err != nil {
+		return err
+	}
+	*in = append(*in, binding)
+	return nil
+}
+
 func (in *VolumeMount) UnmarshalJSON(data []byte) error {
 	if !isString(data) {
 		type volumeMount VolumeMount
@@ -693,6 +828,20 @@ func (in *VolumeMount) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *VolumeSeed) UnmarshalJSON(data []byte) error {
+	if !isString(data) {
+		type volumeSeed VolumeSeed
+		return json.Unmarshal(data, (*volumeSeed)(in))
+	}
+
+	s, err := parseString(data)
+	if err != nil {
+		return err
+	}
+	in.ContextDir = s
+	return nil
+}
+
 func (in *Probe) UnmarshalJSON(data []byte) error {
 	if isString(data) {
 		s, err := parseString(data)
@@ -714,6 +863,10 @@ func (in *Probe) UnmarshalJSON(data []byte) error {
 			in.TCP = &TCPProbe{
 				URL: s,
 			}
+		} else if strings.HasPrefix(s, "grpc://") {
+			in.GRPC = &GRPCProbe{
+				URL: s,
+			}
 		} else {
 			cmd, err := shlex.Split(s)
 			if err != nil {
@@ -737,6 +890,33 @@ func (in *Probe) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *LifecycleHandler) UnmarshalJSON(data []byte) error {
+	if isString(data) {
+		s, err := parseString(data)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+			in.HTTP = &HTTPProbe{
+				URL: s,
+			}
+		} else {
+			cmd, err := shlex.Split(s)
+			if err != nil {
+				return fmt.Errorf("parsing command slice %s: %w", s, err)
+			}
+			in.Exec = &ExecProbe{
+				Command: cmd,
+			}
+		}
+		return nil
+	}
+
+	type lifecycleHandler LifecycleHandler
+	return json.Unmarshal(data, (*lifecycleHandler)(in))
+}
+
 func (in *Probes) UnmarshalJSON(data []byte) error {
 	// ensure not nil if set
 	*in = Probes{}
@@ -859,7 +1039,28 @@ func (in *Files) UnmarshalJSON(data []byte) error {
 func (in *File) UnmarshalJSON(data []byte) error {
 	if isObject(data) {
 		type file File
-		return json.Unmarshal(data, (*file)(in))
+		f := struct {
+			*file
+			Base64   string `json:"base64,omitempty"`
+			Template string `json:"template,omitempty"`
+		}{
+			file: (*file)(in),
+		}
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		if f.Base64 != "" {
+			if _, err := base64.StdEncoding.DecodeString(f.Base64); err != nil {
+				return fmt.Errorf("invalid base64 file content: %w", err)
+			}
+			in.Content = f.Base64
+			in.Binary = true
+		}
+		if f.Template != "" {
+			in.Content = base64.StdEncoding.EncodeToString([]byte(f.Template))
+			in.Interpolate = true
+		}
+		return nil
 	} else if isString(data) {
 		s, err := parseString(data)
 		if err != nil {
@@ -884,6 +1085,7 @@ func (in *File) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	in.Content = string(data)
+	in.Binary = true
 	return nil
 }
 
@@ -973,6 +1175,74 @@ func (in *Build) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *AcornBuild) UnmarshalJSON(data []byte) error {
+	if !isString(data) {
+		type acornBuild AcornBuild
+		return json.Unmarshal(data, (*acornBuild)(in))
+	}
+
+	s, err := parseString(data)
+	if err != nil {
+		return err
+	}
+	in.Context = s
+	return nil
+}
+
+func (in *ResourceRequirement) UnmarshalJSON(data []byte) error {
+	if isObject(data) {
+		var raw struct {
+			Request json.RawMessage `json:"request,omitempty"`
+			Limit   json.RawMessage `json:"limit,omitempty"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		request, err := parseResourceQuantity(raw.Request)
+		if err != nil {
+			return err
+		}
+		limit, err := parseResourceQuantity(raw.Limit)
+		if err != nil {
+			return err
+		}
+		in.Request = request
+		in.Limit = limit
+		return nil
+	}
+
+	q, err := parseResourceQuantity(data)
+	if err != nil {
+		return err
+	}
+	in.Request = q
+	in.Limit = q
+	return nil
+}
+
+// parseResourceQuantity accepts a string or number. Unlike Quantity a plain number is not treated
+// as gigabytes because cpu is expressed in cores.
+func parseResourceQuantity(data []byte) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+	s := string(data)
+	if isString(data) {
+		var err error
+		s, err = parseString(data)
+		if err != nil {
+			return "", err
+		}
+	}
+	if s == "" {
+		return "", nil
+	}
+	if _, err := resource.ParseQuantity(s); err != nil {
+		return "", fmt.Errorf("invalid resource quantity [%s]: %w", s, err)
+	}
+	return s, nil
+}
+
 func isObject(data []byte) bool {
 	return len(data) > 0 && data[0] == '{'
 }
@@ -1005,6 +1275,13 @@ func guessMode(s string) string {
 	return "0644"
 }
 
+// ParseSecretReference parses a reference in the form secret://NAME/KEY?onchange=no-action, which may be wrapped in
+// ${}. False is returned if s is not a secret reference.
+func ParseSecretReference(s string) (SecretReference, bool, error) {
+	result, ok, err := parseSecretReference(s)
+	return result.SecretReference, ok, err
+}
+
 func parseSecretReference(s string) (result secretReference, _ bool, _ error) {
 	if strings.HasPrefix(s, "${secret://") && strings.HasSuffix(s, "}") {
 		s = s[2 : len(s)-1]
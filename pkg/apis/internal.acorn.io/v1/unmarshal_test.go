@@ -21,6 +21,18 @@ func TestParseHostnameBinding(t *testing.T) {
 	}, f[0])
 }
 
+func TestParseGRPCBinding(t *testing.T) {
+	f, err := ParsePortBindings(false, []string{"api:9000/grpc", "80:8080/http2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ProtocolGRPC, f[0].Protocol)
+	assert.Equal(t, ProtocolHTTP2, f[1].Protocol)
+	assert.Equal(t, PublishProtocolGRPCS, f[0].Protocol.PublishProtocol(true))
+	assert.Equal(t, PublishProtocolHTTP, f[1].Protocol.PublishProtocol(false))
+}
+
 func TestParseEnv(t *testing.T) {
 	assert.Nil(t, os.Setenv("x111", "y111"))
 	input := []string{
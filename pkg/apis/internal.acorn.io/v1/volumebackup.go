@@ -0,0 +1,59 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	VolumeBackupInstanceConditionBackup = "backup"
+)
+
+const (
+	VolumeBackupMethodSnapshot = "snapshot"
+	VolumeBackupMethodS3       = "s3"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeBackupInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   VolumeBackupInstanceSpec   `json:"spec,omitempty"`
+	Status VolumeBackupInstanceStatus `json:"status,omitempty"`
+}
+
+type VolumeBackupInstanceSpec struct {
+	// Volume is the name of the volume to back up
+	Volume string `json:"volume,omitempty" wrangler:"required"`
+}
+
+type VolumeBackupInstanceStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// AppName and VolumeName are the app and the name of the volume in the app at the time of the backup
+	AppName    string `json:"appName,omitempty"`
+	VolumeName string `json:"volumeName,omitempty"`
+	// Namespace and ClaimName are the PersistentVolumeClaim the backup was taken from
+	Namespace string `json:"namespace,omitempty"`
+	ClaimName string `json:"claimName,omitempty"`
+	// Method is how the backup was taken, either snapshot or s3
+	Method string `json:"method,omitempty"`
+	// Location is the VolumeSnapshot in the form NAMESPACE/NAME or the URL of the archive
+	Location string `json:"location,omitempty"`
+	// UploadID is the S3 multipart upload the archive is uploaded with
+	UploadID   string      `json:"uploadID,omitempty"`
+	Ready      bool        `json:"ready,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+func (in *VolumeBackupInstance) Conditions() *[]Condition {
+	return &in.Status.Conditions
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeBackupInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeBackupInstance `json:"items"`
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	cue2 "cuelang.org/go/cue"
@@ -14,7 +15,10 @@ import (
 	cue_mod "github.com/acorn-io/acorn/cue.mod"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/cue"
+	"github.com/acorn-io/acorn/pkg/system"
 	"github.com/acorn-io/acorn/schema"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/yaml"
 )
 
@@ -99,6 +103,17 @@ func assignImage(originalImage string, build *v1.Build, image string) (string, *
 	return image, build
 }
 
+// assignSeedImage returns a copy of seed that uses the built image. The content of a context directory is at
+// system.VolumeSeedContextPath in the built image.
+func assignSeedImage(seed *v1.VolumeSeed, image string) *v1.VolumeSeed {
+	result := *seed
+	if result.ContextDir != "" {
+		result.Path = system.VolumeSeedContextPath
+	}
+	result.Image = image
+	return &result
+}
+
 func (a *AppDefinition) getArgsForProfile(args map[string]any, profiles []string) (map[string]any, error) {
 	val, err := a.ctx.Value()
 	if err != nil {
@@ -188,7 +203,7 @@ func (a *AppDefinition) AppSpec() (*v1.AppSpec, error) {
 	}
 
 	objs := map[string]any{}
-	for _, key := range []string{"containers", "jobs", "acorns", "secrets", "volumes", "images", "routers", "labels", "annotations"} {
+	for _, key := range []string{"containers", "jobs", "acorns", "secrets", "volumes", "images", "routers", "services", "labels", "annotations"} {
 		v := app.LookupPath(cue2.ParsePath(key))
 		if v.Exists() {
 			objs[key] = v
@@ -205,6 +220,26 @@ func (a *AppDefinition) AppSpec() (*v1.AppSpec, error) {
 		return nil, err
 	}
 
+	if err := validateFiles(spec); err != nil {
+		return nil, err
+	}
+
+	if err := validatePerReplicaVolumes(spec); err != nil {
+		return nil, err
+	}
+
+	if err := validateRollouts(spec); err != nil {
+		return nil, err
+	}
+
+	if err := validateVolumeSeeds(spec); err != nil {
+		return nil, err
+	}
+
+	if err := validateProbes(spec); err != nil {
+		return nil, err
+	}
+
 	for _, imageData := range a.imageDatas {
 		for c, con := range imageData.Containers {
 			if conSpec, ok := spec.Containers[c]; ok {
@@ -236,11 +271,107 @@ func (a *AppDefinition) AppSpec() (*v1.AppSpec, error) {
 				spec.Images[i] = imgSpec
 			}
 		}
+		for i, img := range imageData.Acorns {
+			if acornSpec, ok := spec.Acorns[i]; ok {
+				acornSpec.Image = img.Image
+				spec.Acorns[i] = acornSpec
+			}
+		}
+		for v, img := range imageData.Volumes {
+			if volumeSpec, ok := spec.Volumes[v]; ok && volumeSpec.Seed != nil {
+				volumeSpec.Seed = assignSeedImage(volumeSpec.Seed, img.Image)
+				spec.Volumes[v] = volumeSpec
+			}
+		}
 	}
 
 	return spec, nil
 }
 
+// validatePerReplicaVolumes checks that per replica volumes are only used by containers. The pods of a job are not
+// replicas that could each get their own volume.
+func validatePerReplicaVolumes(spec *v1.AppSpec) error {
+	for _, job := range typed.Sorted(spec.Jobs) {
+		containers := []v1.Container{job.Value}
+		for _, sidecar := range typed.Sorted(job.Value.Sidecars) {
+			containers = append(containers, sidecar.Value)
+		}
+		for _, container := range containers {
+			for _, dir := range typed.Sorted(container.Dirs) {
+				if dir.Value.Volume != "" && spec.Volumes[dir.Value.Volume].PerReplica {
+					return fmt.Errorf("job %s can not mount per replica volume %s", job.Key, dir.Value.Volume)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateRollouts rejects a rolling update that can neither add nor remove a replica, it would never make progress
+func validateRollouts(spec *v1.AppSpec) error {
+	for _, container := range typed.Sorted(spec.Containers) {
+		rollout := container.Value.Rollout
+		if rollout == nil || rollout.Strategy == v1.RolloutStrategyRecreate {
+			continue
+		}
+		if isZeroIntOrPercent(rollout.MaxSurge) && isZeroIntOrPercent(rollout.MaxUnavailable) {
+			return fmt.Errorf("rollout of container %s can not have both maxSurge and maxUnavailable set to 0", container.Key)
+		}
+	}
+	return nil
+}
+
+// validateVolumeSeeds requires the directory of a seed image to be set, copying the whole image into a volume is
+// almost never what is wanted
+func validateVolumeSeeds(spec *v1.AppSpec) error {
+	for _, volume := range typed.Sorted(spec.Volumes) {
+		if seed := volume.Value.Seed; seed != nil && seed.Image != "" && seed.Path == "" {
+			return fmt.Errorf("seed of volume %s must set the path of the directory to copy from image %s", volume.Key, seed.Image)
+		}
+	}
+	return nil
+}
+
+// validateProbes checks the URLs of grpc probes, a probe with an invalid URL would otherwise be dropped
+func validateProbes(spec *v1.AppSpec) error {
+	for _, containers := range []map[string]v1.Container{spec.Containers, spec.Jobs} {
+		for _, container := range typed.Sorted(containers) {
+			if err := validateContainerProbes(container.Key, container.Value); err != nil {
+				return err
+			}
+			for _, sidecar := range typed.Sorted(container.Value.Sidecars) {
+				if err := validateContainerProbes(sidecar.Key, sidecar.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateContainerProbes(name string, container v1.Container) error {
+	for _, probe := range container.Probes {
+		if probe.GRPC == nil {
+			continue
+		}
+		if _, err := probe.GRPC.Port(); err != nil {
+			return fmt.Errorf("%s probe of container %s: %w", probe.Type, name, err)
+		}
+	}
+	return nil
+}
+
+func isZeroIntOrPercent(value *intstr.IntOrString) bool {
+	if value == nil {
+		return false
+	}
+	if value.Type == intstr.Int {
+		return value.IntVal == 0
+	}
+	i, err := strconv.Atoi(strings.TrimSuffix(value.StrVal, "%"))
+	return err == nil && i == 0
+}
+
 func addContainerFiles(fileSet map[string]bool, builds map[string]v1.ContainerImageBuilderSpec, cwd string) {
 	for _, build := range builds {
 		addContainerFiles(fileSet, build.Sidecars, cwd)
@@ -260,6 +391,23 @@ func addFiles(fileSet map[string]bool, builds map[string]v1.ImageBuilderSpec, cw
 	}
 }
 
+func addAcornFiles(fileSet map[string]bool, builds map[string]v1.AcornBuilderSpec, cwd string) {
+	for _, build := range builds {
+		if build.Build == nil {
+			continue
+		}
+		fileSet[AcornfilePath(build.Build, cwd)] = true
+	}
+}
+
+// AcornfilePath returns the path of the Acornfile for a nested acorn build relative to cwd
+func AcornfilePath(build *v1.AcornBuild, cwd string) string {
+	if build.Acornfile == "" {
+		return filepath.Join(cwd, build.Context, AcornCueFile)
+	}
+	return filepath.Join(cwd, build.Acornfile)
+}
+
 func (a *AppDefinition) WatchFiles(cwd string) (result []string, _ error) {
 	fileSet := map[string]bool{}
 	spec, err := a.BuilderSpec()
@@ -270,6 +418,7 @@ func (a *AppDefinition) WatchFiles(cwd string) (result []string, _ error) {
 	addContainerFiles(fileSet, spec.Containers, cwd)
 	addContainerFiles(fileSet, spec.Jobs, cwd)
 	addFiles(fileSet, spec.Images, cwd)
+	addAcornFiles(fileSet, spec.Acorns, cwd)
 
 	for k := range fileSet {
 		result = append(result, k)
@@ -12,8 +12,50 @@ import (
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+func TestParseServices(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+services: {
+	db: "db.example.com:5432"
+	cache: {
+		address: "10.0.0.5"
+		ports: ["6379", "6380:16380"]
+	}
+	stub: {
+		ports: 80
+	}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, v1.Service{
+		Address: "db.example.com",
+		Ports: v1.Ports{
+			{Port: 5432, TargetPort: 5432},
+		},
+	}, spec.Services["db"])
+	assert.Equal(t, v1.Service{
+		Address: "10.0.0.5",
+		Ports: v1.Ports{
+			{Port: 6379, TargetPort: 6379},
+			{Port: 6380, TargetPort: 16380},
+		},
+	}, spec.Services["cache"])
+	assert.Equal(t, v1.Service{
+		Ports: v1.Ports{
+			{Port: 80, TargetPort: 80},
+		},
+	}, spec.Services["stub"])
+}
+
 func TestParseRouters(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 routers: {
@@ -105,6 +147,143 @@ routers: {
 	}, spec.Routers["foo"])
 }
 
+func TestParseWeightedRouters(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+args: canaryWeight: 10
+routers: web: routes: "/": {
+	targets: [
+		{
+			targetServiceName: "web"
+			weight: 100 - args.canaryWeight
+		},
+		{
+			targetServiceName: "web-canary"
+			targetPort: 8080
+			weight: args.canaryWeight
+		},
+	]
+	stickyCookie: "canary"
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appImage, _, err = appImage.WithArgs(map[string]any{"canaryWeight": 25}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []v1.Route{
+		{
+			Path:     "/",
+			PathType: v1.PathTypePrefix,
+			Targets: []v1.WeightedTarget{
+				{
+					TargetServiceName: "web",
+					Weight:            &[]int{75}[0],
+				},
+				{
+					TargetServiceName: "web-canary",
+					TargetPort:        8080,
+					Weight:            &[]int{25}[0],
+				},
+			},
+			StickyCookie: "canary",
+		},
+	}, []v1.Route(spec.Routers["web"].Routes))
+}
+
+func TestParseRouterMatch(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+routers: web: routes: [
+	{
+		path: "/api"
+		targetServiceName: "api-canary"
+		match: {
+			methods: ["GET"]
+			headers: "X-Canary": "true"
+		}
+	},
+	{
+		path: "/api"
+		targetServiceName: "api"
+		stripPrefix: true
+	},
+	{
+		path: "/v1"
+		targetServiceName: "api"
+		rewrite: "/api"
+	},
+	{
+		path: "/old"
+		pathType: "exact"
+		redirect: "https://example.com/new"
+	},
+	{
+		path: "/older"
+		redirect: {
+			url: "https://example.com"
+			code: 301
+		}
+	},
+]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []v1.Route{
+		{
+			Path:              "/api",
+			PathType:          v1.PathTypePrefix,
+			TargetServiceName: "api-canary",
+			Match: &v1.RouteMatch{
+				Methods: []string{"GET"},
+				Headers: map[string]string{
+					"X-Canary": "true",
+				},
+			},
+		},
+		{
+			Path:              "/api",
+			PathType:          v1.PathTypePrefix,
+			TargetServiceName: "api",
+			StripPrefix:       true,
+		},
+		{
+			Path:              "/v1",
+			PathType:          v1.PathTypePrefix,
+			TargetServiceName: "api",
+			Rewrite:           "/api",
+		},
+		{
+			Path:     "/old",
+			PathType: v1.PathTypeExact,
+			Redirect: &v1.RouteRedirect{
+				URL:  "https://example.com/new",
+				Code: 302,
+			},
+		},
+		{
+			Path:     "/older",
+			PathType: v1.PathTypePrefix,
+			Redirect: &v1.RouteRedirect{
+				URL:  "https://example.com",
+				Code: 301,
+			},
+		},
+	}, []v1.Route(spec.Routers["web"].Routes))
+}
+
 func TestParse5GLiteralVolume(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 volumes: {
@@ -936,6 +1115,97 @@ containers: {
 	assert.Equal(t, "123", appSpec.Containers["s"].Sidecars["left"].Files["/bin/secret.sh"].Mode)
 }
 
+func TestBinaryFiles(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+containers: s: {
+	image: "x"
+	files: {
+		"/etc/base64": base64: "AAEC/w=="
+		"/etc/bytes": base64: '\x00\x01\x02\xff'
+		"/etc/app.yaml": template: "name: ${app.name}"
+		"/etc/key.pem": {
+			content: "a2V5"
+			sensitive: true
+			mode: "0400"
+		}
+	}
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		errors.Print(os.Stderr, err, nil)
+		t.Fatal(err)
+	}
+
+	files := appSpec.Containers["s"].Files
+	assert.Equal(t, "AAEC/w==", files["/etc/base64"].Content)
+	assert.True(t, files["/etc/base64"].Binary)
+	assert.Equal(t, "AAEC/w==", files["/etc/bytes"].Content)
+	assert.True(t, files["/etc/bytes"].Binary)
+	assert.False(t, files["/etc/bytes"].Sensitive)
+	assert.Equal(t, "a2V5", files["/etc/key.pem"].Content)
+	assert.False(t, files["/etc/key.pem"].Binary)
+	assert.False(t, files["/etc/key.pem"].Interpolate)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("name: ${app.name}")), files["/etc/app.yaml"].Content)
+	assert.True(t, files["/etc/app.yaml"].Interpolate)
+	assert.Equal(t, "0400", files["/etc/key.pem"].Mode)
+	assert.True(t, files["/etc/key.pem"].Sensitive)
+}
+
+func TestFileSizeLimits(t *testing.T) {
+	content := func(size int) string {
+		return base64.StdEncoding.EncodeToString(make([]byte, size))
+	}
+
+	err := validateFiles(&v1.AppSpec{
+		Containers: map[string]v1.Container{
+			"web": {
+				Files: v1.Files{
+					"/small":  {Content: content(10)},
+					"/large":  {Content: content(v1.MaxFileSize + 1)},
+					"/secret": {Secret: v1.SecretReference{Name: "sec", Key: "key"}},
+				},
+				Sidecars: map[string]v1.Container{
+					"side": {
+						Files: v1.Files{
+							"/large": {Content: content(v1.MaxFileSize + 2)},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.EqualError(t, err, `files can not be larger than 1048564 bytes: containers.web.files["/large"] (1048565 bytes), `+
+		`containers.web.sidecars.side.files["/large"] (1048566 bytes)`)
+
+	files := v1.Files{}
+	for i := 0; i < 10; i++ {
+		files["/file"+strconv.Itoa(i)] = v1.File{Content: content(50_000 + i*1_000)}
+	}
+	err = validateFiles(&v1.AppSpec{
+		Jobs: map[string]v1.Container{
+			"job": {
+				Files: files,
+			},
+		},
+	})
+	assert.EqualError(t, err, `the files of the app can not be larger than 524288 bytes in total, the largest files are: `+
+		`jobs.job.files["/file9"] (59000 bytes)`)
+
+	files["/file9"] = v1.File{Content: content(10)}
+	assert.NoError(t, validateFiles(&v1.AppSpec{
+		Jobs: map[string]v1.Container{
+			"job": {
+				Files: files,
+			},
+		},
+	}))
+}
+
 func TestImageBuildPermutations(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 containers: {
@@ -982,7 +1252,9 @@ images: {
 	}
 
 	assert.Equal(t, &v1.BuilderSpec{
-		Jobs: map[string]v1.ContainerImageBuilderSpec{},
+		Jobs:    map[string]v1.ContainerImageBuilderSpec{},
+		Acorns:  map[string]v1.AcornBuilderSpec{},
+		Volumes: map[string]v1.VolumeBuilderSpec{},
 		Containers: map[string]v1.ContainerImageBuilderSpec{
 			"image": {
 				Image: "image-image",
@@ -1285,6 +1557,147 @@ volumes: {
 	assert.Equal(t, "./sub", appSpec.Containers["s"].Dirs["/var/named-context-vol"].ContextDir)
 }
 
+func TestVolumeSeeds(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+containers: s: {
+  image: "x"
+  dirs: {
+    "/var/context": "context"
+    "/var/image": "image"
+  }
+}
+
+volumes: {
+  context: seed: "./fixtures"
+  image: seed: {
+    image: "seed-image"
+    path: "/data"
+  }
+  none: {}
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buildSpec, err := appImage.BuilderSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &v1.VolumeSeed{ContextDir: "./fixtures"}, buildSpec.Volumes["context"].Seed)
+	assert.Equal(t, &v1.VolumeSeed{Image: "seed-image", Path: "/data"}, buildSpec.Volumes["image"].Seed)
+	assert.Nil(t, buildSpec.Volumes["none"].Seed)
+
+	appImage = appImage.WithImageData(v1.ImagesData{
+		Volumes: map[string]v1.ImageData{
+			"context": {
+				Image: "context-digest",
+			},
+			"image": {
+				Image: "image-digest",
+			},
+		},
+	})
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &v1.VolumeSeed{
+		ContextDir: "./fixtures",
+		Image:      "context-digest",
+		Path:       "/acorn-seed",
+	}, appSpec.Volumes["context"].Seed)
+	assert.Equal(t, &v1.VolumeSeed{
+		Image: "image-digest",
+		Path:  "/data",
+	}, appSpec.Volumes["image"].Seed)
+	assert.Nil(t, appSpec.Volumes["none"].Seed)
+}
+
+func TestVolumeSeedRequiresPath(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`
+containers: s: {
+  image: "x"
+  dirs: "/var/image": "image"
+}
+
+volumes: image: seed: image: "seed-image"
+`))
+	assert.EqualError(t, err, "seed of volume image must set the path of the directory to copy from image seed-image")
+}
+
+func TestMemoryVolumes(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+containers: s: {
+  image: "x"
+  dirs: {
+    "/dev/shm": "volume://shm"
+    "/cache": "cache"
+  }
+}
+
+volumes: {
+  shm: medium: "memory"
+  cache: {
+    medium: "memory"
+    size: "1Gi"
+  }
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, v1.VolumeMediumMemory, appSpec.Volumes["shm"].Medium)
+	assert.Equal(t, v1.VolumeRequestTypeEphemeral, appSpec.Volumes["shm"].Class)
+	// The default size of a volume reference does not apply to memory
+	assert.Equal(t, v1.Quantity("64Mi"), appSpec.Volumes["shm"].Size)
+	assert.Equal(t, v1.VolumeMediumMemory, appSpec.Volumes["cache"].Medium)
+	assert.Equal(t, v1.Quantity("1Gi"), appSpec.Volumes["cache"].Size)
+}
+
+func TestPerReplicaVolumes(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+containers: db: {
+  image: "x"
+  scale: 3
+  dirs: "/var/lib/db": "data"
+}
+
+volumes: data: perReplica: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, appSpec.Volumes["data"].PerReplica)
+
+	_, err = NewAppDefinition([]byte(`
+jobs: setup: {
+  image: "x"
+  sidecars: side: {
+    image: "y"
+    dirs: "/var/lib/db": "data"
+  }
+}
+
+volumes: data: perReplica: true
+`))
+	assert.EqualError(t, err, "job setup can not mount per replica volume data")
+}
+
 func TestSecrets(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 containers: {
@@ -1415,6 +1828,122 @@ jobs: job2: image: "job2-image"
 	assert.Equal(t, "job2-image", appSpec.Jobs["job2"].Image)
 }
 
+func TestAcorns(t *testing.T) {
+	acornCue := `
+acorns: {
+	db: {
+		image: "ghcr.io/acorn-io/library/mariadb"
+		profiles: ["prod"]
+		deployArgs: dbName: "app"
+		ports: expose: "db:3306"
+		secrets: ["admin:db-admin"]
+		volumes: ["data-pv:data"]
+		links: ["web:api"]
+		labels: "containers:tier": "data"
+	}
+	cache: {
+		build: "./cache"
+		ports: 6379
+	}
+	worker: build: {
+		context: "./worker"
+		acornfile: "./worker/Acornfile.prod"
+		buildArgs: debug: true
+	}
+}
+`
+	app, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := app.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, v1.Acorn{
+		Image:    "ghcr.io/acorn-io/library/mariadb",
+		Profiles: []string{"prod"},
+		DeployArgs: v1.GenericMap{
+			"dbName": "app",
+		},
+		Ports: v1.PortBindings{
+			{
+				Expose:            true,
+				TargetPort:        3306,
+				TargetServiceName: "db",
+			},
+		},
+		Secrets: []v1.SecretBinding{
+			{
+				Secret: "admin",
+				Target: "db-admin",
+			},
+		},
+		Volumes: []v1.VolumeBinding{
+			{
+				Volume: "data-pv",
+				Target: "data",
+			},
+		},
+		Links: []v1.ServiceBinding{
+			{
+				Service: "web",
+				Target:  "api",
+			},
+		},
+		Labels: v1.ScopedLabels{
+			{
+				ResourceType: v1.LabelTypeContainer,
+				Key:          "tier",
+				Value:        "data",
+			},
+		},
+		Annotations: v1.ScopedLabels{},
+		Environment: v1.NameValues{},
+	}, appSpec.Acorns["db"])
+
+	assert.Equal(t, &v1.AcornBuild{
+		Context: "./cache",
+	}, appSpec.Acorns["cache"].Build)
+	assert.Equal(t, v1.PortBindings{
+		{
+			Expose:     true,
+			Port:       6379,
+			TargetPort: 6379,
+		},
+	}, appSpec.Acorns["cache"].Ports)
+
+	assert.Equal(t, &v1.AcornBuild{
+		Context:   "./worker",
+		Acornfile: "./worker/Acornfile.prod",
+		BuildArgs: v1.GenericMap{
+			"debug": true,
+		},
+	}, appSpec.Acorns["worker"].Build)
+
+	files, err := app.WatchFiles("root-path")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{
+		filepath.Join("root-path", "cache", "Acornfile"),
+		filepath.Join("root-path", "worker", "Acornfile.prod"),
+	}, files)
+}
+
+func TestAcornNonUnique(t *testing.T) {
+	acornCue := `
+containers: db: image: "test"
+acorns: db: image: "test"
+`
+	_, err := NewAppDefinition([]byte(acornCue))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "duplicate name [db] used by")
+}
+
 func TestNonUnique(t *testing.T) {
 	acornCue := `
 containers: foo: image: "test"
@@ -1498,6 +2027,189 @@ containers: zero: scale: 0
 	assert.Equal(t, int32(0), *appSpec.Containers["zero"].Scale)
 }
 
+func TestAutoscale(t *testing.T) {
+	acornCue := `
+containers: web: scale: {
+	min: 2
+	max: 10
+	memoryUtilization: 70
+}
+containers: worker: scale: max: 5
+containers: api: scale: 3
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, (*int32)(nil), appSpec.Containers["web"].Scale)
+	assert.Equal(t, &v1.Autoscale{
+		Min:               &[]int32{2}[0],
+		Max:               10,
+		MemoryUtilization: &[]int32{70}[0],
+	}, appSpec.Containers["web"].Autoscale)
+	assert.Equal(t, &v1.Autoscale{
+		Min: &[]int32{1}[0],
+		Max: 5,
+	}, appSpec.Containers["worker"].Autoscale)
+	assert.Equal(t, &[]int32{3}[0], appSpec.Containers["api"].Scale)
+	assert.Nil(t, appSpec.Containers["api"].Autoscale)
+
+	_, err = NewAppDefinition([]byte(`containers: web: scale: {min: 3, max: 2}`))
+	assert.Error(t, err)
+}
+
+func TestRolloutNoProgress(t *testing.T) {
+	for _, acornCue := range []string{
+		`containers: web: rollout: {maxSurge: 0, maxUnavailable: 0}`,
+		`containers: web: rollout: {maxSurge: "0%", maxUnavailable: 0}`,
+	} {
+		_, err := NewAppDefinition([]byte(acornCue))
+		assert.EqualError(t, err, "rollout of container web can not have both maxSurge and maxUnavailable set to 0")
+	}
+
+	for _, acornCue := range []string{
+		`containers: web: rollout: {maxSurge: 1, maxUnavailable: 0}`,
+		`containers: web: rollout: {strategy: "recreate", maxSurge: 0, maxUnavailable: 0}`,
+	} {
+		_, err := NewAppDefinition([]byte(acornCue))
+		assert.NoError(t, err)
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	acornCue := `
+containers: web: {
+	scale: 3
+	availability: minAvailable: "50%"
+}
+containers: api: {
+	scale: 2
+	availability: {
+		minAvailable: 1
+		spreadAcross: "zone"
+	}
+}
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, intstr.FromString("50%"), *appSpec.Containers["web"].Availability.MinAvailable)
+	assert.Equal(t, v1.SpreadAcrossNode, appSpec.Containers["web"].Availability.SpreadAcross)
+	assert.Equal(t, intstr.FromInt(1), *appSpec.Containers["api"].Availability.MinAvailable)
+	assert.Equal(t, v1.SpreadAcrossZone, appSpec.Containers["api"].Availability.SpreadAcross)
+
+	_, err = NewAppDefinition([]byte(`containers: web: availability: spreadAcross: "region"`))
+	assert.Error(t, err)
+}
+
+func TestSecurity(t *testing.T) {
+	acornCue := `
+containers: web: {
+	security: {
+		runAsUser: 1000
+		readOnlyRootFilesystem: true
+		capabilities: {
+			add: ["NET_ADMIN"]
+			drop: ["ALL"]
+		}
+	}
+	sidecars: proxy: security: {
+		privileged: true
+		hostNetwork: true
+	}
+}
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	security := appSpec.Containers["web"].Security
+	assert.Equal(t, int64(1000), *security.RunAsUser)
+	assert.True(t, security.ReadOnlyRootFilesystem)
+	assert.Equal(t, []string{"capability:NET_ADMIN"}, security.Escalations())
+	assert.Equal(t, []string{"ALL"}, security.Capabilities.Drop)
+	assert.Equal(t, []string{"privileged", "hostNetwork"}, appSpec.Containers["web"].Sidecars["proxy"].Security.Escalations())
+
+	_, err = NewAppDefinition([]byte(`containers: web: security: capabilities: add: ["net_admin"]`))
+	assert.Error(t, err)
+}
+
+func TestLifecycle(t *testing.T) {
+	acornCue := `
+containers: web: {
+	stopGracePeriod: "1m"
+	lifecycle: {
+		preStop: "/bin/drain --timeout 60"
+		postStart: http: url: "http://localhost:8080/warm"
+	}
+}
+jobs: job: stopGracePeriod: "30s"
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycle := appSpec.Containers["web"].Lifecycle
+	assert.Equal(t, "1m", appSpec.Containers["web"].StopGracePeriod)
+	assert.Equal(t, []string{"/bin/drain", "--timeout", "60"}, lifecycle.PreStop.Exec.Command)
+	assert.Equal(t, "http://localhost:8080/warm", lifecycle.PostStart.HTTP.URL)
+	assert.Equal(t, "30s", appSpec.Jobs["job"].StopGracePeriod)
+
+	_, err = NewAppDefinition([]byte(`containers: web: stopGracePeriod: "forever"`))
+	assert.Error(t, err)
+	_, err = NewAppDefinition([]byte(`containers: web: sidecars: side: stopGracePeriod: "1m"`))
+	assert.Error(t, err)
+}
+
+func TestJobEvents(t *testing.T) {
+	acornCue := `
+jobs: migrate: events: ["create", "update"]
+jobs: cleanup: events: ["delete"]
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{v1.JobEventCreate, v1.JobEventUpdate}, appSpec.Jobs["migrate"].Events)
+	assert.Equal(t, []string{v1.JobEventDelete}, appSpec.Jobs["cleanup"].Events)
+
+	_, err = NewAppDefinition([]byte(`jobs: migrate: events: ["restart"]`))
+	assert.Error(t, err)
+	_, err = NewAppDefinition([]byte(`containers: web: events: ["create"]`))
+	assert.Error(t, err)
+}
+
 func TestBuildProfileParameters(t *testing.T) {
 	acornCue := `
 args: {
@@ -2233,6 +2945,26 @@ containers: test: {
 	assert.Equal(t, "blah", appSpec.Containers["test"].Dirs["/foo3"].Volume)
 }
 
+func TestGRPCProbeURL(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`
+containers: api: probe: "grpc://localhost:9000"
+`))
+	assert.NoError(t, err)
+
+	_, err = NewAppDefinition([]byte(`
+containers: api: probe: "grpc://localhost"
+`))
+	assert.EqualError(t, err, "readiness probe of container api: invalid grpc probe url [grpc://localhost], must be in the form grpc://HOST:PORT")
+
+	_, err = NewAppDefinition([]byte(`
+jobs: migrate: sidecars: health: probes: [{
+	type: "liveness"
+	grpc: url: "grpc://localhost:port"
+}]
+`))
+	assert.EqualError(t, err, "liveness probe of container health: invalid grpc probe url [grpc://localhost:port]: parse \"grpc://localhost:port\": invalid port \":port\" after host")
+}
+
 func TestDisableProbes(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 containers: map: probes: {}
@@ -2293,3 +3025,52 @@ func TestNestedScopedLabels(t *testing.T) {
 	}}`))
 	assert.Error(t, err)
 }
+
+func TestResources(t *testing.T) {
+	acornCue := `
+containers: {
+	web: {
+		image: "nginx"
+		memory: "512Mi"
+		cpu: 0.5
+		sidecars: sidecar: {
+			image: "busybox"
+			memory: {
+				request: "64Mi"
+				limit: "128Mi"
+			}
+		}
+	}
+}
+jobs: job: {
+	image: "busybox"
+	cpu: request: "100m"
+}
+`
+	app, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := app.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, &v1.ResourceRequirement{Request: "512Mi", Limit: "512Mi"}, appSpec.Containers["web"].Memory)
+	assert.Equal(t, &v1.ResourceRequirement{Request: "0.5", Limit: "0.5"}, appSpec.Containers["web"].CPU)
+	assert.Equal(t, &v1.ResourceRequirement{Request: "64Mi", Limit: "128Mi"}, appSpec.Containers["web"].Sidecars["sidecar"].Memory)
+	assert.Nil(t, appSpec.Jobs["job"].Memory)
+	assert.Equal(t, &v1.ResourceRequirement{Request: "100m"}, appSpec.Jobs["job"].CPU)
+}
+
+func TestResourcesInvalid(t *testing.T) {
+	acornCue := `
+containers: web: {
+	image: "nginx"
+	memory: "lots"
+}
+`
+	_, err := NewAppDefinition([]byte(acornCue))
+	assert.ErrorContains(t, err, "invalid resource quantity [lots]")
+}
@@ -0,0 +1,95 @@
+package appdefinition
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/baaah/pkg/typed"
+)
+
+type fileSize struct {
+	name string
+	size int
+}
+
+// validateFiles checks that the content of every file fits in a ConfigMap or Secret and that the files of the app
+// together do not exceed v1.MaxFilesSize
+func validateFiles(spec *v1.AppSpec) error {
+	var (
+		sizes []fileSize
+		total int
+	)
+	for _, containers := range []struct {
+		kind       string
+		containers map[string]v1.Container
+	}{
+		{"containers", spec.Containers},
+		{"jobs", spec.Jobs},
+	} {
+		for _, entry := range typed.Sorted(containers.containers) {
+			prefix := containers.kind + "." + entry.Key
+			fileSizes, err := containerFileSizes(prefix, entry.Value.Files)
+			if err != nil {
+				return err
+			}
+			sizes = append(sizes, fileSizes...)
+			for _, sidecar := range typed.Sorted(entry.Value.Sidecars) {
+				fileSizes, err := containerFileSizes(prefix+".sidecars."+sidecar.Key, sidecar.Value.Files)
+				if err != nil {
+					return err
+				}
+				sizes = append(sizes, fileSizes...)
+			}
+		}
+	}
+
+	var tooLarge []string
+	for _, size := range sizes {
+		if size.size > v1.MaxFileSize {
+			tooLarge = append(tooLarge, fmt.Sprintf("%s (%d bytes)", size.name, size.size))
+		}
+		total += size.size
+	}
+	if len(tooLarge) > 0 {
+		return fmt.Errorf("files can not be larger than %d bytes: %s", v1.MaxFileSize, strings.Join(tooLarge, ", "))
+	}
+	if total <= v1.MaxFilesSize {
+		return nil
+	}
+
+	// Name the largest files that together are over the limit
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].size > sizes[j].size
+	})
+	var largest []string
+	for _, size := range sizes {
+		largest = append(largest, fmt.Sprintf("%s (%d bytes)", size.name, size.size))
+		total -= size.size
+		if total <= v1.MaxFilesSize {
+			break
+		}
+	}
+	return fmt.Errorf("the files of the app can not be larger than %d bytes in total, the largest files are: %s",
+		v1.MaxFilesSize, strings.Join(largest, ", "))
+}
+
+func containerFileSizes(prefix string, files v1.Files) (result []fileSize, _ error) {
+	for _, entry := range typed.Sorted(files) {
+		if entry.Value.Secret.Name != "" {
+			continue
+		}
+		name := fmt.Sprintf("%s.files[%q]", prefix, entry.Key)
+		content, err := base64.StdEncoding.DecodeString(entry.Value.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content of %s: %w", name, err)
+		}
+		result = append(result, fileSize{
+			name: name,
+			size: len(content),
+		})
+	}
+	return
+}
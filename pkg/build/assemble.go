@@ -134,6 +134,16 @@ func digestOnly(imageData v1.ImagesData) (result v1.ImagesData, err error) {
 	}
 
 	result.Images, err = digestOnlyImages(imageData.Images)
+	if err != nil {
+		return
+	}
+
+	result.Acorns, err = digestOnlyImages(imageData.Acorns)
+	if err != nil {
+		return
+	}
+
+	result.Volumes, err = digestOnlyImages(imageData.Volumes)
 	return
 }
 
@@ -156,6 +166,18 @@ func allImages(data v1.ImagesData, opts []remote.Option) (result []mutate.IndexA
 	}
 	result = append(result, remoteImages...)
 
+	remoteImages, err = images(data.Acorns, opts)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, remoteImages...)
+
+	remoteImages, err = images(data.Volumes, opts)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, remoteImages...)
+
 	return
 }
 
@@ -14,8 +14,10 @@ import (
 	"github.com/acorn-io/acorn/pkg/build/buildkit"
 	"github.com/acorn-io/acorn/pkg/buildclient"
 	"github.com/acorn-io/acorn/pkg/cue"
+	"github.com/acorn-io/acorn/pkg/system"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 )
 
@@ -66,7 +68,7 @@ func Build(ctx context.Context, messages buildclient.Messages, pushRepo string,
 	}
 	buildSpec.Platforms = opts.Platforms
 
-	imageData, err := FromSpec(ctx, pushRepo, *buildSpec, messages, keychain, remoteOpts)
+	imageData, err := FromSpec(ctx, pushRepo, *buildSpec, opts.Acorns, messages, keychain, remoteOpts)
 	appImage := &v1.AppImage{
 		Acornfile: opts.Acornfile,
 		ImageData: imageData,
@@ -173,7 +175,114 @@ func buildImages(ctx context.Context, pushRepo string, buildCache *buildCache, p
 	return result, nil
 }
 
-func FromSpec(ctx context.Context, pushRepo string, spec v1.BuilderSpec, messages buildclient.Messages, keychain authn.Keychain, opts []remote.Option) (v1.ImagesData, error) {
+// buildVolumes builds the images that seed volumes. A seed from a context directory is copied into an empty image at
+// system.VolumeSeedContextPath and a seed image is copied into pushRepo.
+func buildVolumes(ctx context.Context, pushRepo string, buildCache *buildCache, platforms []v1.Platform, messages buildclient.Messages, volumes map[string]v1.VolumeBuilderSpec, keychain authn.Keychain, opts []remote.Option) (map[string]v1.ImageData, error) {
+	result := map[string]v1.ImageData{}
+
+	for _, entry := range typed.Sorted(volumes) {
+		key, seed := entry.Key, entry.Value.Seed
+		if seed == nil {
+			continue
+		}
+
+		var build v1.Build
+		switch {
+		case seed.ContextDir != "":
+			build = v1.Build{
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+				DockerfileContents: toContextCopyDockerFile("scratch", map[string]string{
+					system.VolumeSeedContextPath: seed.ContextDir,
+				}),
+			}
+		case seed.Image != "":
+			build = v1.Build{
+				BaseImage: seed.Image,
+			}
+		default:
+			return nil, fmt.Errorf("either image or a context directory must be set on the seed of volume [%s]", key)
+		}
+
+		id, err := fromBuild(ctx, pushRepo, buildCache, platforms, build, messages, keychain, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = v1.ImageData{
+			Image: id,
+		}
+	}
+
+	return result, nil
+}
+
+// buildAcorns resolves the app images of nested acorns. Acorns that specify a build must already be built by the client
+// and passed in as prebuilt, while acorns that reference an image have that image copied into pushRepo.
+func buildAcorns(ctx context.Context, pushRepo string, acorns map[string]v1.AcornBuilderSpec, prebuilt map[string]v1.ImageData, opts []remote.Option) (map[string]v1.ImageData, error) {
+	result := map[string]v1.ImageData{}
+
+	repo, err := name.NewRepository(pushRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range typed.Sorted(acorns) {
+		key, acorn := entry.Key, entry.Value
+
+		if built, ok := prebuilt[key]; ok && built.Image != "" {
+			result[key] = v1.ImageData{
+				Image: repo.Digest("sha256:" + strings.TrimPrefix(built.Image, "sha256:")).String(),
+			}
+			continue
+		}
+
+		if acorn.Image == "" {
+			if acorn.Build != nil {
+				return nil, fmt.Errorf("nested acorn [%s] has a build but was not built before its parent", key)
+			}
+			return nil, fmt.Errorf("either image or build field must be set on acorn [%s]", key)
+		}
+
+		id, err := copyAcornImage(ctx, repo, acorn.Image, opts)
+		if err != nil {
+			return nil, fmt.Errorf("copying image for acorn [%s]: %w", key, err)
+		}
+
+		result[key] = v1.ImageData{
+			Image: id,
+		}
+	}
+
+	return result, nil
+}
+
+func copyAcornImage(ctx context.Context, repo name.Repository, image string, opts []remote.Option) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+
+	opts = append([]remote.Option{remote.WithContext(ctx)}, opts...)
+	index, err := remote.Index(ref, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := index.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	target := repo.Digest(h.String())
+	if err := remote.WriteIndex(target, index, opts...); err != nil {
+		return "", err
+	}
+
+	return target.String(), nil
+}
+
+func FromSpec(ctx context.Context, pushRepo string, spec v1.BuilderSpec, acorns map[string]v1.ImageData, messages buildclient.Messages, keychain authn.Keychain, opts []remote.Option) (v1.ImagesData, error) {
 	var (
 		err  error
 		data = v1.ImagesData{
@@ -198,6 +307,16 @@ func FromSpec(ctx context.Context, pushRepo string, spec v1.BuilderSpec, message
 		return data, err
 	}
 
+	data.Acorns, err = buildAcorns(ctx, pushRepo, spec.Acorns, acorns, opts)
+	if err != nil {
+		return data, err
+	}
+
+	data.Volumes, err = buildVolumes(ctx, pushRepo, buildCache, spec.Platforms, messages, spec.Volumes, keychain, opts)
+	if err != nil {
+		return data, err
+	}
+
 	return data, nil
 }
 
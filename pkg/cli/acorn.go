@@ -41,6 +41,7 @@ func New() *cobra.Command {
 		NewCheck(cmdContext),
 		NewContainer(cmdContext),
 		NewController(cmdContext),
+		NewCp(cmdContext),
 		NewCredential(cmdContext),
 		NewRender(cmdContext),
 		NewExec(cmdContext),
@@ -48,6 +49,7 @@ func New() *cobra.Command {
 		NewInstall(cmdContext),
 		NewUninstall(cmdContext),
 		NewInfo(cmdContext),
+		NewJob(cmdContext),
 		NewLogs(cmdContext),
 		NewCredentialLogin(true, cmdContext),
 		NewCredentialLogout(true, cmdContext),
@@ -62,6 +64,7 @@ func New() *cobra.Command {
 		NewStop(cmdContext),
 		NewTag(cmdContext),
 		NewVolume(cmdContext),
+		NewVolumeHelper(cmdContext),
 		NewWait(cmdContext),
 	)
 	root.InitDefaultHelpCmd()
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/cp"
+	"github.com/acorn-io/acorn/pkg/progressbar"
+	"github.com/spf13/cobra"
+)
+
+func NewCp(c CommandContext) *cobra.Command {
+	return cli.Command(&Cp{client: c.ClientFactory}, cobra.Command{
+		Use: "cp [flags] SOURCE DESTINATION",
+		Example: `
+# Copy a local file into a container
+acorn cp ./dump.sql my-app.db:/tmp/dump.sql
+
+# Copy a directory out of a container
+acorn cp my-app.web:/var/log ./logs
+
+# Copy a file into a directory of a volume
+acorn cp ./seed.json volume:pvc-ab12cd34:/data/`,
+		SilenceUsage: true,
+		Short:        "Copy files and directories between the local filesystem and a container or volume",
+		Long: `Copy files and directories between the local filesystem and a container or volume.
+
+A container is given as APP_NAME.CONTAINER_NAME:PATH or CONTAINER_REPLICA_NAME:PATH and a volume as
+volume:VOLUME_NAME:PATH. The volume is mounted in a short lived pod for the copy. The container must have sh and tar.
+
+A destination ending in a slash is a directory the source is copied into.`,
+		Args: cobra.ExactArgs(2),
+	})
+}
+
+type Cp struct {
+	client ClientFactory
+}
+
+func (s *Cp) Run(cmd *cobra.Command, args []string) error {
+	c, err := s.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	progress := make(chan client.ImageProgress)
+	go func() {
+		defer close(progress)
+		if err := cp.Copy(cmd.Context(), c, args[0], args[1], progress); err != nil {
+			progress <- client.ImageProgress{
+				Error: err.Error(),
+			}
+		}
+	}()
+
+	return progressbar.Print(progress)
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/spf13/cobra"
+)
+
+func NewJob(c CommandContext) *cobra.Command {
+	cmd := cli.Command(&Job{}, cobra.Command{
+		Use:     "job [flags]",
+		Aliases: []string{"jobs"},
+		Example: `
+acorn job run my-app.migrate`,
+		SilenceUsage: true,
+		Short:        "Manage jobs",
+		Args:         cobra.NoArgs,
+	})
+	cmd.AddCommand(NewJobRun(c))
+	return cmd
+}
+
+type Job struct {
+}
+
+func (a *Job) Run(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+func NewJobRun(c CommandContext) *cobra.Command {
+	return cli.Command(&JobRun{client: c.ClientFactory}, cobra.Command{
+		Use: "run [flags] APP_NAME.JOB_NAME",
+		Example: `
+acorn job run my-app.migrate`,
+		SilenceUsage:      true,
+		Short:             "Run a job of an app and follow its logs until it finishes",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: newCompletion(c.ClientFactory, appsCompletion).withShouldCompleteOptions(onlyNumArgs(1)).complete,
+	})
+}
+
+type JobRun struct {
+	client ClientFactory
+}
+
+func (a *JobRun) Run(cmd *cobra.Command, args []string) error {
+	appName, jobName, ok := strings.Cut(args[0], ".")
+	if !ok || appName == "" || jobName == "" {
+		return fmt.Errorf("invalid job [%s], must be in the form APP_NAME.JOB_NAME", args[0])
+	}
+
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	jobRun, err := c.AppRunJob(cmd.Context(), appName, jobName)
+	if err != nil {
+		return err
+	}
+
+	return log.JobRunOutput(cmd.Context(), c, appName, jobRun.Job)
+}
@@ -53,6 +53,10 @@ func NewRun(c CommandContext) *cobra.Command {
   # Link the running acorn application named "mydatabase" into the current app, replacing the container named "db"
   acorn run --link mydatabase:db .
 
+# Service Syntax
+  # Point the service named "db" in the services section of the app at a database that does not run in acorn
+  acorn run --service db=db.example.com:5432 .
+
 # Secret Syntax
   # Bind the acorn secret named "mycredentials" into the current app, replacing the secret named "creds". See "acorn secrets --help" for more info
   acorn run --secret mycredentials:creds .
@@ -114,11 +118,15 @@ type RunArgs struct {
 	Volume          []string `usage:"Bind an existing volume (format existing:vol-name,field=value) (ex: pvc-name:app-data)" short:"v" split:"false"`
 	Secret          []string `usage:"Bind an existing secret (format existing:sec-name) (ex: sec-name:app-secret)" short:"s"`
 	Link            []string `usage:"Link external app as a service in the current app (format app-name:container-name)"`
+	Service         []string `usage:"Bind a service declared in the services section of the app to an address (format service-name=host[:port]) (ex: db=db.example.com:5432)"`
 	PublishAll      *bool    `usage:"Publish all (true) or none (false) of the defined ports of application" short:"P"`
 	Publish         []string `usage:"Publish port of application (format [public:]private) (ex 81:80)" short:"p"`
 	Expose          []string `usage:"In cluster expose ports of an application (format [public:]private) (ex 81:80)"`
 	Profile         []string `usage:"Profile to assign default values"`
 	Env             []string `usage:"Environment variables to set on running containers" short:"e"`
+	Memory          []string `usage:"Set memory request and limit for a container, or all containers if the name is omitted (format [containername=]memory) (ex: web=512Mi)"`
+	CPU             []string `usage:"Set cpu request and limit for a container, or all containers if the name is omitted (format [containername=]cpu) (ex: web=500m)"`
+	ComputeClass    []string `usage:"Set the compute class for a container, or all containers if the name is omitted (format [containername=]class) (ex: web=large)"`
 	Label           []string `usage:"Add labels to the app and the resources it creates (format [type:][name:]key=value) (ex k=v, containers:k=v)" short:"l"`
 	Annotation      []string `usage:"Add annotations to the app and the resources it creates (format [type:][name:]key=value) (ex k=v, containers:k=v)"`
 	Dangerous       bool     `usage:"Automatically approve all privileges requested by the application"`
@@ -157,8 +165,28 @@ func (s RunArgs) ToOpts() (client.AppRunOptions, error) {
 		return opts, err
 	}
 
+	opts.ExternalServices, err = v1.ParseExternalServices(s.Service)
+	if err != nil {
+		return opts, err
+	}
+
 	opts.Env = v1.ParseNameValues(true, s.Env...)
 
+	opts.Memory, err = v1.ParseResources(s.Memory)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.CPU, err = v1.ParseResources(s.CPU)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.ComputeClass, err = v1.ParseComputeClasses(s.ComputeClass)
+	if err != nil {
+		return opts, err
+	}
+
 	opts.Labels, err = v1.ParseScopedLabels(s.Label...)
 	if err != nil {
 		return opts, err
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"time"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/cli/builder/table"
+	"github.com/spf13/cobra"
+)
+
+func NewSecretHistory(c CommandContext) *cobra.Command {
+	cmd := cli.Command(&SecretHistory{client: c.ClientFactory}, cobra.Command{
+		Use: "history [flags] [SECRET_NAME...]",
+		Example: `
+acorn secret history my-app.db-password`,
+		SilenceUsage:      true,
+		Short:             "Show when the values of generated secrets were rotated",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: newCompletion(c.ClientFactory, secretsCompletion).complete,
+	})
+	return cmd
+}
+
+type SecretHistory struct {
+	Quiet  bool   `usage:"Output only names" short:"q"`
+	Output string `usage:"Output format (json, yaml, {{gotemplate}})" short:"o"`
+	client ClientFactory
+}
+
+type historyEntry struct {
+	Name      string
+	RotatedAt string
+	Current   bool
+}
+
+func (a *SecretHistory) Run(cmd *cobra.Command, args []string) error {
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	out := table.NewWriter([][]string{
+		{"NAME", "Name"},
+		{"ROTATED", "RotatedAt"},
+		{"CURRENT", "{{boolToStar .Current}}"},
+	}, a.Quiet, a.Output)
+
+	for _, arg := range args {
+		secret, err := client.SecretGet(cmd.Context(), arg)
+		if err != nil {
+			return err
+		}
+
+		// Newest first
+		for i := len(secret.Rotations) - 1; i >= 0; i-- {
+			out.Write(&historyEntry{
+				Name:      arg,
+				RotatedAt: secret.Rotations[i].UTC().Format(time.RFC3339),
+				Current:   i == len(secret.Rotations)-1,
+			})
+		}
+	}
+
+	return out.Err()
+}
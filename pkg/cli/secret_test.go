@@ -221,6 +221,44 @@ func TestSecret(t *testing.T) {
 			wantErr: false,
 			wantOut: "ACORNENC:e30\n",
 		},
+		{
+			name: "acorn secret history rotated.secret", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"history", "rotated.secret"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "NAME             ROTATED                CURRENT\nrotated.secret   2023-01-31T00:00:00Z   *\nrotated.secret   2023-01-01T00:00:00Z   \n",
+		},
+		{
+			name: "acorn secret history dne", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"history", "dne"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "error: Secret dne does not exist",
+		},
 	}
 	for _, tt := range tests {
 		r, w, _ := os.Pipe()
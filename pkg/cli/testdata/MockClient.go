@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
@@ -166,7 +167,12 @@ func (m *MockClient) AppLog(ctx context.Context, name string, opts *client.LogOp
 	switch name {
 	case "found":
 		progresses := make(chan apiv1.LogMessage)
-		close(progresses)
+		go func() {
+			defer close(progresses)
+			if opts != nil && opts.JobRun != "" {
+				progresses <- apiv1.LogMessage{JobRunResult: "Complete"}
+			}
+		}()
 		return progresses, nil
 	case "dne":
 		progresses := make(chan apiv1.LogMessage)
@@ -244,6 +250,16 @@ func (m *MockClient) SecretGet(ctx context.Context, name string) (*apiv1.Secret,
 	switch name {
 	case "dne":
 		return nil, fmt.Errorf("error: Secret %s does not exist", name)
+	case "rotated.secret":
+		return &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "rotated.secret"},
+			Type:       "token",
+			Keys:       []string{"previous-token", "token"},
+			Rotations: []metav1.Time{
+				metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+				metav1.NewTime(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		}, nil
 	case "found.secret":
 		return &apiv1.Secret{
 			TypeMeta:   metav1.TypeMeta{},
@@ -381,6 +397,61 @@ func (m *MockClient) VolumeDelete(ctx context.Context, name string) (*apiv1.Volu
 	return nil, nil
 }
 
+func (m *MockClient) VolumeBackupCreate(ctx context.Context, volumeName string) (*apiv1.VolumeBackup, error) {
+	switch volumeName {
+	case "found.volume":
+		return &apiv1.VolumeBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "found.backup"},
+			Spec:       v1.VolumeBackupInstanceSpec{Volume: volumeName},
+		}, nil
+	}
+	return nil, fmt.Errorf("error: volume %s does not exist", volumeName)
+}
+
+func (m *MockClient) VolumeBackupList(ctx context.Context) ([]apiv1.VolumeBackup, error) {
+	return []apiv1.VolumeBackup{{
+		ObjectMeta: metav1.ObjectMeta{Name: "found.backup"},
+		Spec:       v1.VolumeBackupInstanceSpec{Volume: "found.volume"},
+		Status: v1.VolumeBackupInstanceStatus{
+			AppName:    "found",
+			VolumeName: "found.volume",
+			Method:     v1.VolumeBackupMethodS3,
+			Ready:      true,
+		},
+	}}, nil
+}
+
+func (m *MockClient) VolumeBackupGet(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	switch name {
+	case "found.backup":
+		return &apiv1.VolumeBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "found.backup"},
+			Spec:       v1.VolumeBackupInstanceSpec{Volume: "found.volume"},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) VolumeBackupDelete(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	switch name {
+	case "found.backup":
+		return &apiv1.VolumeBackup{}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) VolumeBackupRestore(ctx context.Context, name, volumeName string) (*apiv1.VolumeBackupRestore, error) {
+	switch name {
+	case "found.backup":
+		return &apiv1.VolumeBackupRestore{
+			Volume:  volumeName,
+			AppName: "found",
+			Job:     "acorn-restore-found.backup-abcde",
+		}, nil
+	}
+	return nil, fmt.Errorf("error: backup %s does not exist", name)
+}
+
 func (m *MockClient) ImageList(ctx context.Context) ([]apiv1.Image, error) {
 	return []apiv1.Image{{
 		TypeMeta:   metav1.TypeMeta{},
@@ -525,6 +596,17 @@ func (m *MockClient) AppConfirmUpgrade(ctx context.Context, name string) error {
 	return nil
 }
 
+func (m *MockClient) AppRunJob(ctx context.Context, name, jobName string) (*apiv1.JobRun, error) {
+	switch name {
+	case "dne":
+		return nil, fmt.Errorf("error: app %s does not exist", name)
+	}
+	return &apiv1.JobRun{
+		JobName: jobName,
+		Job:     jobName + "-run-abcde",
+	}, nil
+}
+
 func (m *MockClient) AcornImageBuildGet(ctx context.Context, name string) (*apiv1.AcornImageBuild, error) {
 	//TODO implement me
 	panic("implement me")
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/cli/builder/table"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/tables"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeBackup(c CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeBackup{client: c.ClientFactory}, cobra.Command{
+		Use: "backup [flags] [VOLUME_NAME...]",
+		Example: `
+# List all backups
+acorn volume backup
+
+# Back up a volume
+acorn volume backup pvc-ab12cd34`,
+		SilenceUsage:      true,
+		Short:             "Back up volumes or list backups",
+		ValidArgsFunction: newCompletion(c.ClientFactory, volumesCompletion).complete,
+	})
+	cmd.AddCommand(NewVolumeBackupDelete(c))
+	return cmd
+}
+
+type VolumeBackup struct {
+	Quiet  bool   `usage:"Output only names" short:"q"`
+	Output string `usage:"Output format (json, yaml, {{gotemplate}})" short:"o"`
+	client ClientFactory
+}
+
+func (a *VolumeBackup) Run(cmd *cobra.Command, args []string) error {
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		for _, volume := range args {
+			backup, err := c.VolumeBackupCreate(cmd.Context(), volume)
+			if err != nil {
+				return fmt.Errorf("backing up %s: %w", volume, err)
+			}
+			fmt.Println(backup.Name)
+		}
+		return nil
+	}
+
+	out := table.NewWriter(tables.VolumeBackup, a.Quiet, a.Output)
+
+	backups, err := c.VolumeBackupList(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups {
+		out.Write(backup)
+	}
+
+	return out.Err()
+}
+
+func NewVolumeBackupDelete(c CommandContext) *cobra.Command {
+	return cli.Command(&VolumeBackupDelete{client: c.ClientFactory}, cobra.Command{
+		Use:               "rm [BACKUP_NAME...]",
+		Example:           `acorn volume backup rm my-app-volume-ab12c`,
+		SilenceUsage:      true,
+		Short:             "Delete a volume backup",
+		ValidArgsFunction: newCompletion(c.ClientFactory, volumeBackupsCompletion).complete,
+	})
+}
+
+type VolumeBackupDelete struct {
+	client ClientFactory
+}
+
+func (a *VolumeBackupDelete) Run(cmd *cobra.Command, args []string) error {
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range args {
+		deleted, err := c.VolumeBackupDelete(cmd.Context(), backup)
+		if err != nil {
+			return fmt.Errorf("deleting %s: %w", backup, err)
+		}
+		if deleted != nil {
+			fmt.Println(backup)
+		} else {
+			fmt.Printf("Error: No such volume backup: %s\n", backup)
+		}
+	}
+
+	return nil
+}
+
+func volumeBackupsCompletion(ctx context.Context, c client.Client, toComplete string) ([]string, error) {
+	backups, err := c.VolumeBackupList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, backup := range backups {
+		if strings.HasPrefix(backup.Name, toComplete) {
+			result = append(result, backup.Name)
+		}
+	}
+
+	return result, nil
+}
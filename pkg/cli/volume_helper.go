@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeHelper(c CommandContext) *cobra.Command {
+	return cli.Command(&VolumeHelper{}, cobra.Command{
+		Use:          "volume-helper [upload UPLOAD_FILE DIR | download URL DIR | copy SOURCE_DIR DIR | seed SOURCE_DIR DIR]",
+		SilenceUsage: true,
+		Hidden:       true,
+		Short:        "Copy the contents of a volume for a backup, restore or seed",
+		Args:         cobra.ExactArgs(3),
+	})
+}
+
+type VolumeHelper struct {
+}
+
+func (s *VolumeHelper) Run(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "upload":
+		return volumebackup.Upload(cmd.Context(), args[1], args[2])
+	case "download":
+		return volumebackup.Download(cmd.Context(), args[1], args[2])
+	case "copy":
+		return volumebackup.CopyDir(args[1], args[2])
+	case "seed":
+		return volumebackup.Seed(args[1], args[2])
+	default:
+		return fmt.Errorf("invalid action [%s], must be upload, download, copy or seed", args[0])
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeRestore(c CommandContext) *cobra.Command {
+	return cli.Command(&VolumeRestore{client: c.ClientFactory}, cobra.Command{
+		Use: "restore [flags] BACKUP_NAME",
+		Example: `
+acorn volume restore my-app-volume-ab12c --to pvc-ab12cd34`,
+		SilenceUsage:      true,
+		Short:             "Replace the contents of a volume with a backup and follow the restore until it finishes",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: newCompletion(c.ClientFactory, volumeBackupsCompletion).withShouldCompleteOptions(onlyNumArgs(1)).complete,
+	})
+}
+
+type VolumeRestore struct {
+	To     string `usage:"Volume to restore the backup to (default is the volume the backup was taken from)"`
+	client ClientFactory
+}
+
+func (a *VolumeRestore) Run(cmd *cobra.Command, args []string) error {
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	to := a.To
+	if to == "" {
+		backup, err := c.VolumeBackupGet(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		to = backup.Spec.Volume
+	}
+
+	restore, err := c.VolumeBackupRestore(cmd.Context(), args[0], to)
+	if err != nil {
+		return err
+	}
+
+	return log.JobRunOutput(cmd.Context(), c, restore.AppName, restore.Job)
+}
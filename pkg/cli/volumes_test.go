@@ -164,6 +164,101 @@ func TestVolume(t *testing.T) {
 			wantErr: true,
 			wantOut: "Error: No such volume: dne\n",
 		},
+		{
+			name: "acorn volume backup", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"backup"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "NAME           VOLUME         APP-NAME   BOUND-VOLUME   METHOD    READY     CREATED\nfound.backup   found.volume   found      found.volume   s3        *         292y ago\n",
+		},
+		{
+			name: "acorn volume backup found.volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"backup", "found.volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "found.backup\n",
+		},
+		{
+			name: "acorn volume backup dne", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"backup", "dne"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "backing up dne: error: volume dne does not exist",
+		},
+		{
+			name: "acorn volume restore found.backup", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"restore", "found.backup"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "",
+		},
+		{
+			name: "acorn volume restore dne --to found.volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"restore", "dne", "--to", "found.volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "error: backup dne does not exist",
+		},
 	}
 	for _, tt := range tests {
 		r, w, _ := os.Pipe()
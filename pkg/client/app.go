@@ -47,11 +47,15 @@ func ToApp(namespace, image string, opts *AppRunOptions) *apiv1.App {
 			Volumes:             opts.Volumes,
 			Secrets:             opts.Secrets,
 			Links:               opts.Links,
+			ExternalServices:    opts.ExternalServices,
 			Ports:               opts.Ports,
 			Profiles:            opts.Profiles,
 			DevMode:             opts.DevMode,
 			Permissions:         opts.Permissions,
 			Environment:         opts.Env,
+			Memory:              opts.Memory,
+			CPU:                 opts.CPU,
+			ComputeClass:        opts.ComputeClass,
 			Labels:              opts.Labels,
 			Annotations:         opts.Annotations,
 			TargetNamespace:     opts.TargetNamespace,
@@ -108,11 +112,15 @@ func ToAppUpdate(ctx context.Context, c Client, name string, opts *AppUpdateOpti
 	app.Spec.Volumes = mergeVolumes(app.Spec.Volumes, opts.Volumes)
 	app.Spec.Secrets = mergeSecrets(app.Spec.Secrets, opts.Secrets)
 	app.Spec.Links = mergeServices(app.Spec.Links, opts.Links)
+	app.Spec.ExternalServices = mergeExternalServices(app.Spec.ExternalServices, opts.ExternalServices)
 	app.Spec.Ports = mergePorts(app.Spec.Ports, opts.Ports)
 	app.Spec.Environment = mergeEnv(app.Spec.Environment, opts.Env)
 	app.Spec.Labels = mergeLabels(app.Spec.Labels, opts.Labels)
 	app.Spec.Annotations = mergeLabels(app.Spec.Annotations, opts.Annotations)
 	app.Spec.DeployArgs = typed.Concat(app.Spec.DeployArgs, opts.DeployArgs)
+	app.Spec.Memory = typed.Concat(app.Spec.Memory, opts.Memory)
+	app.Spec.CPU = typed.Concat(app.Spec.CPU, opts.CPU)
+	app.Spec.ComputeClass = typed.Concat(app.Spec.ComputeClass, opts.ComputeClass)
 	if len(opts.Profiles) > 0 {
 		app.Spec.Profiles = opts.Profiles
 	}
@@ -274,6 +282,24 @@ func mergeServices(appServices, optsServices []v1.ServiceBinding) []v1.ServiceBi
 	return appServices
 }
 
+func mergeExternalServices(appServices, optsServices []v1.ExternalServiceBinding) []v1.ExternalServiceBinding {
+	for _, newService := range optsServices {
+		found := false
+		for i, existingService := range appServices {
+			if existingService.Target == newService.Target {
+				appServices[i] = newService
+				found = true
+				break
+			}
+		}
+		if !found {
+			appServices = append(appServices, newService)
+		}
+	}
+
+	return appServices
+}
+
 func mergeSecrets(appSecrets, optsSecrets []v1.SecretBinding) []v1.SecretBinding {
 	for _, newSecret := range optsSecrets {
 		found := false
@@ -475,3 +501,25 @@ func (c *client) AppPullImage(ctx context.Context, name string) error {
 		SubResource("pullimage").
 		Body(&apiv1.AppPullImage{}).Do(ctx).Error()
 }
+
+func (c *client) AppRunJob(ctx context.Context, name, jobName string) (*apiv1.JobRun, error) {
+	app := &apiv1.App{}
+	err := c.Client.Get(ctx, kclient.ObjectKey{
+		Name:      name,
+		Namespace: c.Namespace,
+	}, app)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &apiv1.JobRun{}
+	err = c.RESTClient.Post().
+		Namespace(app.Namespace).
+		Resource("apps").
+		Name(app.Name).
+		SubResource("jobrun").
+		Body(&apiv1.JobRun{
+			JobName: jobName,
+		}).Do(ctx).Into(result)
+	return result, err
+}
@@ -2,13 +2,16 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/appdefinition"
 	"github.com/acorn-io/acorn/pkg/build"
 	"github.com/acorn-io/acorn/pkg/buildclient"
 	"github.com/acorn-io/acorn/pkg/cue"
+	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -56,6 +59,11 @@ func (c *client) AcornImageBuild(ctx context.Context, file string, opts *AcornIm
 
 	vcs := build.VCS(filepath.Dir(file))
 
+	acorns, err := c.buildNestedAcorns(ctx, fileData, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	builder, err := c.getOrCreateBuilder(ctx, opts.BuilderName)
 	if err != nil {
 		return nil, err
@@ -74,6 +82,7 @@ func (c *client) AcornImageBuild(ctx context.Context, file string, opts *AcornIm
 			Args:        opts.Args,
 			Profiles:    opts.Profiles,
 			VCS:         vcs,
+			Acorns:      acorns,
 		},
 	}
 
@@ -95,3 +104,48 @@ func (c *client) AcornImageBuild(ctx context.Context, file string, opts *AcornIm
 	logrus.Debugf("Building with URL: %s", build.Status.BuildURL)
 	return buildclient.Stream(ctx, opts.Cwd, opts.Streams, dialer, (buildclient.CredentialLookup)(opts.Credentials), build)
 }
+
+// buildNestedAcorns builds the Acornfiles of the nested acorns that have a build section. The builder only has access
+// to the parent Acornfile, so the nested ones are built first and their image IDs are passed along to the parent build.
+func (c *client) buildNestedAcorns(ctx context.Context, fileData []byte, opts *AcornImageBuildOptions) (map[string]v1.ImageData, error) {
+	appDefinition, err := appdefinition.NewAppDefinition(fileData)
+	if err != nil {
+		return nil, err
+	}
+
+	appDefinition, _, err = appDefinition.WithArgs(opts.Args, append([]string{"build?"}, opts.Profiles...))
+	if err != nil {
+		return nil, err
+	}
+
+	buildSpec, err := appDefinition.BuilderSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]v1.ImageData{}
+	for _, entry := range typed.Sorted(buildSpec.Acorns) {
+		acornName, acornBuild := entry.Key, entry.Value.Build
+		if acornBuild == nil {
+			continue
+		}
+
+		appImage, err := c.AcornImageBuild(ctx, appdefinition.AcornfilePath(acornBuild, opts.Cwd), &AcornImageBuildOptions{
+			BuilderName: opts.BuilderName,
+			Credentials: opts.Credentials,
+			Cwd:         filepath.Join(opts.Cwd, acornBuild.Context),
+			Platforms:   opts.Platforms,
+			Args:        acornBuild.BuildArgs,
+			Streams:     opts.Streams,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("building acorn [%s]: %w", acornName, err)
+		}
+
+		result[acornName] = v1.ImageData{
+			Image: appImage.ID,
+		}
+	}
+
+	return result, nil
+}
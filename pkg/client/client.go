@@ -83,12 +83,16 @@ type AppUpdateOptions struct {
 	Volumes             []v1.VolumeBinding
 	Secrets             []v1.SecretBinding
 	Links               []v1.ServiceBinding
+	ExternalServices    []v1.ExternalServiceBinding
 	Ports               []v1.PortBinding
 	Env                 []v1.NameValue
 	Profiles            []string
 	Permissions         []v1.Permissions
 	DeployArgs          map[string]any
 	DevMode             *bool
+	Memory              v1.ResourceMap
+	CPU                 v1.ResourceMap
+	ComputeClass        v1.ComputeClassMap
 	Image               string
 	TargetNamespace     string
 	Replace             bool // Replace is used to indicate whether the update should be a patch (replace=false: only change specified fields) or a full update (replace=true: reset unspecified fields to defaults)
@@ -107,6 +111,7 @@ type AppRunOptions struct {
 	Volumes             []v1.VolumeBinding
 	Secrets             []v1.SecretBinding
 	Links               []v1.ServiceBinding
+	ExternalServices    []v1.ExternalServiceBinding
 	Ports               []v1.PortBinding
 	Env                 []v1.NameValue
 	Profiles            []string
@@ -114,6 +119,9 @@ type AppRunOptions struct {
 	DeployArgs          map[string]any
 	DevMode             *bool
 	Permissions         []v1.Permissions
+	Memory              v1.ResourceMap
+	CPU                 v1.ResourceMap
+	ComputeClass        v1.ComputeClassMap
 	AutoUpgrade         *bool
 	NotifyUpgrade       *bool
 	AutoUpgradeInterval string
@@ -127,12 +135,16 @@ func (a AppRunOptions) ToUpdate() AppUpdateOptions {
 		Volumes:             a.Volumes,
 		Secrets:             a.Secrets,
 		Links:               a.Links,
+		ExternalServices:    a.ExternalServices,
 		Ports:               a.Ports,
 		DeployArgs:          a.DeployArgs,
 		DevMode:             a.DevMode,
 		Profiles:            a.Profiles,
 		Permissions:         a.Permissions,
 		Env:                 a.Env,
+		Memory:              a.Memory,
+		CPU:                 a.CPU,
+		ComputeClass:        a.ComputeClass,
 		TargetNamespace:     a.TargetNamespace,
 		AutoUpgrade:         a.AutoUpgrade,
 		NotifyUpgrade:       a.NotifyUpgrade,
@@ -148,12 +160,16 @@ func (a AppUpdateOptions) ToRun() AppRunOptions {
 		Volumes:             a.Volumes,
 		Secrets:             a.Secrets,
 		Links:               a.Links,
+		ExternalServices:    a.ExternalServices,
 		Ports:               a.Ports,
 		DeployArgs:          a.DeployArgs,
 		DevMode:             a.DevMode,
 		Profiles:            a.Profiles,
 		Permissions:         a.Permissions,
 		Env:                 a.Env,
+		Memory:              a.Memory,
+		CPU:                 a.CPU,
+		ComputeClass:        a.ComputeClass,
 		TargetNamespace:     a.TargetNamespace,
 		AutoUpgrade:         a.AutoUpgrade,
 		NotifyUpgrade:       a.NotifyUpgrade,
@@ -185,6 +201,7 @@ type Client interface {
 	AppLog(ctx context.Context, name string, opts *LogOptions) (<-chan apiv1.LogMessage, error)
 	AppConfirmUpgrade(ctx context.Context, name string) error
 	AppPullImage(ctx context.Context, name string) error
+	AppRunJob(ctx context.Context, name, jobName string) (*apiv1.JobRun, error)
 
 	CredentialCreate(ctx context.Context, serverAddress, username, password string, skipChecks bool) (*apiv1.Credential, error)
 	CredentialList(ctx context.Context) ([]apiv1.Credential, error)
@@ -207,6 +224,11 @@ type Client interface {
 	VolumeList(ctx context.Context) ([]apiv1.Volume, error)
 	VolumeGet(ctx context.Context, name string) (*apiv1.Volume, error)
 	VolumeDelete(ctx context.Context, name string) (*apiv1.Volume, error)
+	VolumeBackupCreate(ctx context.Context, volumeName string) (*apiv1.VolumeBackup, error)
+	VolumeBackupList(ctx context.Context) ([]apiv1.VolumeBackup, error)
+	VolumeBackupGet(ctx context.Context, name string) (*apiv1.VolumeBackup, error)
+	VolumeBackupDelete(ctx context.Context, name string) (*apiv1.VolumeBackup, error)
+	VolumeBackupRestore(ctx context.Context, name, volumeName string) (*apiv1.VolumeBackupRestore, error)
 
 	ImageList(ctx context.Context) ([]apiv1.Image, error)
 	ImageGet(ctx context.Context, name string) (*apiv1.Image, error)
@@ -277,6 +299,8 @@ type ImageDeleteOptions struct {
 
 type ContainerReplicaExecOptions struct {
 	DebugImage string `json:"debugImage,omitempty"`
+	// Volume runs the command in a new pod that mounts the volume at system.VolumeExecMountPath
+	Volume string `json:"volume,omitempty"`
 }
 
 type ContainerReplicaListOptions struct {
@@ -20,6 +20,7 @@ func (c *client) execContainer(ctx context.Context, container *apiv1.ContainerRe
 			TTY:        tty,
 			Command:    args,
 			DebugImage: opts.DebugImage,
+			Volume:     opts.Volume,
 		}, scheme.ParameterCodec)
 
 	logrus.Debugf("Exec URL: %s", req.URL().String())
@@ -32,7 +33,7 @@ func (c *client) execContainer(ctx context.Context, container *apiv1.ContainerRe
 }
 
 func (c *client) ContainerReplicaExec(ctx context.Context, containerName string, args []string, tty bool, opts *ContainerReplicaExecOptions) (*term.ExecIO, error) {
-	if containerName == "_" && opts != nil && opts.DebugImage != "" {
+	if containerName == "_" && opts != nil && (opts.DebugImage != "" || opts.Volume != "") {
 		return c.execContainer(ctx, &apiv1.ContainerReplica{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "_",
@@ -123,6 +123,10 @@ func (c IgnoreUninstalled) AppConfirmUpgrade(ctx context.Context, name string) e
 	return c.Client.AppConfirmUpgrade(ctx, name)
 }
 
+func (c IgnoreUninstalled) AppRunJob(ctx context.Context, name, jobName string) (*apiv1.JobRun, error) {
+	return c.Client.AppRunJob(ctx, name, jobName)
+}
+
 func (c *IgnoreUninstalled) AppLog(ctx context.Context, name string, opts *LogOptions) (<-chan apiv1.LogMessage, error) {
 	return c.Client.AppLog(ctx, name, opts)
 }
@@ -155,6 +159,26 @@ func (c IgnoreUninstalled) VolumeDelete(ctx context.Context, name string) (*apiv
 	return ignoreUninstalled(c.Client.VolumeDelete(ctx, name))
 }
 
+func (c IgnoreUninstalled) VolumeBackupCreate(ctx context.Context, volumeName string) (*apiv1.VolumeBackup, error) {
+	return c.Client.VolumeBackupCreate(ctx, volumeName)
+}
+
+func (c IgnoreUninstalled) VolumeBackupList(ctx context.Context) ([]apiv1.VolumeBackup, error) {
+	return ignoreUninstalled(c.Client.VolumeBackupList(ctx))
+}
+
+func (c IgnoreUninstalled) VolumeBackupGet(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	return c.Client.VolumeBackupGet(ctx, name)
+}
+
+func (c IgnoreUninstalled) VolumeBackupDelete(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	return ignoreUninstalled(c.Client.VolumeBackupDelete(ctx, name))
+}
+
+func (c IgnoreUninstalled) VolumeBackupRestore(ctx context.Context, name, volumeName string) (*apiv1.VolumeBackupRestore, error) {
+	return c.Client.VolumeBackupRestore(ctx, name, volumeName)
+}
+
 func (c IgnoreUninstalled) ImageList(ctx context.Context) ([]apiv1.Image, error) {
 	return ignoreUninstalled(c.Client.ImageList(ctx))
 }
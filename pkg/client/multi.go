@@ -175,6 +175,12 @@ func (m *MultiClient) AppPullImage(ctx context.Context, name string) error {
 	return err
 }
 
+func (m *MultiClient) AppRunJob(ctx context.Context, name, jobName string) (*apiv1.JobRun, error) {
+	return onOne(ctx, m.factory, name, func(name string, c Client) (*apiv1.JobRun, error) {
+		return c.AppRunJob(ctx, name, jobName)
+	})
+}
+
 func (m *MultiClient) CredentialCreate(ctx context.Context, serverAddress, username, password string, skipChecks bool) (*apiv1.Credential, error) {
 	return onOne(ctx, m.factory, serverAddress, func(name string, c Client) (*apiv1.Credential, error) {
 		return c.CredentialCreate(ctx, name, username, password, skipChecks)
@@ -285,6 +291,36 @@ func (m *MultiClient) VolumeDelete(ctx context.Context, name string) (*apiv1.Vol
 	})
 }
 
+func (m *MultiClient) VolumeBackupCreate(ctx context.Context, volumeName string) (*apiv1.VolumeBackup, error) {
+	return onOne(ctx, m.factory, volumeName, func(name string, c Client) (*apiv1.VolumeBackup, error) {
+		return c.VolumeBackupCreate(ctx, name)
+	})
+}
+
+func (m *MultiClient) VolumeBackupList(ctx context.Context) ([]apiv1.VolumeBackup, error) {
+	return aggregate(ctx, m.factory, func(c Client) ([]apiv1.VolumeBackup, error) {
+		return c.VolumeBackupList(ctx)
+	})
+}
+
+func (m *MultiClient) VolumeBackupGet(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	return onOne(ctx, m.factory, name, func(name string, c Client) (*apiv1.VolumeBackup, error) {
+		return c.VolumeBackupGet(ctx, name)
+	})
+}
+
+func (m *MultiClient) VolumeBackupDelete(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	return onOne(ctx, m.factory, name, func(name string, c Client) (*apiv1.VolumeBackup, error) {
+		return c.VolumeBackupDelete(ctx, name)
+	})
+}
+
+func (m *MultiClient) VolumeBackupRestore(ctx context.Context, name, volumeName string) (*apiv1.VolumeBackupRestore, error) {
+	return onOne(ctx, m.factory, name, func(name string, c Client) (*apiv1.VolumeBackupRestore, error) {
+		return c.VolumeBackupRestore(ctx, name, volumeName)
+	})
+}
+
 func (m *MultiClient) ImageList(ctx context.Context) ([]apiv1.Image, error) {
 	c, err := m.factory.ForProject(ctx, m.factory.DefaultProject())
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,3 +51,76 @@ func (c *client) VolumeDelete(ctx context.Context, name string) (*apiv1.Volume,
 		},
 	})
 }
+
+func (c *client) VolumeBackupCreate(ctx context.Context, volumeName string) (*apiv1.VolumeBackup, error) {
+	vol, err := c.VolumeGet(ctx, volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	generateName := volumeName + "-"
+	if vol.Status.AppName != "" && vol.Status.VolumeName != "" {
+		generateName = vol.Status.AppName + "-" + vol.Status.VolumeName + "-"
+	}
+
+	backup := &apiv1.VolumeBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: generateName,
+			Namespace:    c.Namespace,
+		},
+		Spec: v1.VolumeBackupInstanceSpec{
+			Volume: volumeName,
+		},
+	}
+	return backup, c.Client.Create(ctx, backup)
+}
+
+func (c *client) VolumeBackupList(ctx context.Context) ([]apiv1.VolumeBackup, error) {
+	backups := &apiv1.VolumeBackupList{}
+	err := c.Client.List(ctx, backups, &kclient.ListOptions{
+		Namespace: c.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(backups.Items, func(i, j int) bool {
+		if backups.Items[i].CreationTimestamp.Time == backups.Items[j].CreationTimestamp.Time {
+			return backups.Items[i].Name < backups.Items[j].Name
+		}
+		return backups.Items[i].CreationTimestamp.After(backups.Items[j].CreationTimestamp.Time)
+	})
+
+	return backups.Items, nil
+}
+
+func (c *client) VolumeBackupGet(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	backup := &apiv1.VolumeBackup{}
+	return backup, c.Client.Get(ctx, kclient.ObjectKey{
+		Name:      name,
+		Namespace: c.Namespace,
+	}, backup)
+}
+
+func (c *client) VolumeBackupDelete(ctx context.Context, name string) (*apiv1.VolumeBackup, error) {
+	backup, err := c.VolumeBackupGet(ctx, name)
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return backup, c.Client.Delete(ctx, backup)
+}
+
+func (c *client) VolumeBackupRestore(ctx context.Context, name, volumeName string) (*apiv1.VolumeBackupRestore, error) {
+	result := &apiv1.VolumeBackupRestore{}
+	err := c.RESTClient.Post().
+		Namespace(c.Namespace).
+		Resource("volumebackups").
+		Name(name).
+		SubResource("restore").
+		Body(&apiv1.VolumeBackupRestore{
+			Volume: volumeName,
+		}).Do(ctx).Into(result)
+	return result, err
+}
@@ -212,6 +212,20 @@ func merge(oldConfig, newConfig *apiv1.Config) *apiv1.Config {
 			mergedConfig.IngressClassName = newConfig.IngressClassName
 		}
 	}
+	if newConfig.Gateway != nil {
+		if *newConfig.Gateway == "" {
+			mergedConfig.Gateway = nil
+		} else {
+			mergedConfig.Gateway = newConfig.Gateway
+		}
+	}
+	if newConfig.GatewayListener != nil {
+		if *newConfig.GatewayListener == "" {
+			mergedConfig.GatewayListener = nil
+		} else {
+			mergedConfig.GatewayListener = newConfig.GatewayListener
+		}
+	}
 	if newConfig.SetPodSecurityEnforceProfile == nil {
 		mergedConfig.SetPodSecurityEnforceProfile = newConfig.SetPodSecurityEnforceProfile
 	}
@@ -267,6 +281,25 @@ func merge(oldConfig, newConfig *apiv1.Config) *apiv1.Config {
 	if newConfig.InternalRegistryPrefix != nil {
 		mergedConfig.InternalRegistryPrefix = newConfig.InternalRegistryPrefix
 	}
+	if newConfig.VolumeSnapshotClass != nil {
+		if *newConfig.VolumeSnapshotClass == "" {
+			mergedConfig.VolumeSnapshotClass = nil
+		} else {
+			mergedConfig.VolumeSnapshotClass = newConfig.VolumeSnapshotClass
+		}
+	}
+	if newConfig.VolumeBackupURL != nil {
+		if *newConfig.VolumeBackupURL == "" {
+			mergedConfig.VolumeBackupURL = nil
+		} else {
+			mergedConfig.VolumeBackupURL = newConfig.VolumeBackupURL
+		}
+	}
+	if len(newConfig.SecretProviders) > 0 && newConfig.SecretProviders[0] == "" {
+		mergedConfig.SecretProviders = nil
+	} else if len(newConfig.SecretProviders) > 0 {
+		mergedConfig.SecretProviders = newConfig.SecretProviders
+	}
 
 	return &mergedConfig
 }
@@ -0,0 +1,139 @@
+package appdefinition
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/condition"
+	"github.com/acorn-io/acorn/pkg/images"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/ports"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"github.com/google/go-containerregistry/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func addAcorns(appInstance *v1.AppInstance, tag name.Reference, resp router.Response) {
+	resp.Objects(toAcorns(appInstance, tag)...)
+}
+
+func toAcorns(appInstance *v1.AppInstance, tag name.Reference) (result []kclient.Object) {
+	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Acorns) {
+		if ports.IsLinked(appInstance, entry.Key) {
+			continue
+		}
+		result = append(result, toAcorn(appInstance, tag, entry.Key, entry.Value))
+	}
+	return result
+}
+
+func toAcorn(appInstance *v1.AppInstance, tag name.Reference, acornName string, acorn v1.Acorn) *v1.AppInstance {
+	image := acorn.Image
+	if tag != nil {
+		image = images.ResolveTag(tag, acorn.Image)
+	}
+
+	return &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      acornName,
+			Namespace: appInstance.Status.Namespace,
+			Labels: labels.Merge(labels.GatherScoped(acornName, v1.LabelTypeAcorn, appInstance.Status.AppSpec.Labels,
+				nil, appInstance.Spec.Labels), labels.Managed(appInstance, labels.AcornAcornName, acornName)),
+			Annotations: labels.GatherScoped(acornName, v1.LabelTypeAcorn, appInstance.Status.AppSpec.Annotations,
+				nil, appInstance.Spec.Annotations),
+		},
+		Spec: v1.AppInstanceSpec{
+			Labels:      acorn.Labels,
+			Annotations: acorn.Annotations,
+			Image:       image,
+			Stop:        appInstance.Spec.Stop,
+			Profiles:    acorn.Profiles,
+			DeployArgs:  acorn.DeployArgs,
+			Volumes:     acorn.Volumes,
+			Secrets:     acorn.Secrets,
+			Environment: acorn.Environment,
+			Links:       acorn.Links,
+			Ports:       acorn.Ports,
+		},
+	}
+}
+
+// AcornStatus rolls up the status of the child apps created from the acorns section into the parent app
+func AcornStatus(req router.Request, resp router.Response) error {
+	var (
+		app    = req.Object.(*v1.AppInstance)
+		cond   = condition.Setter(app, resp, v1.AppInstanceConditionAcorns)
+		acorns = &v1.AppInstanceList{}
+	)
+
+	err := req.List(acorns, &kclient.ListOptions{
+		Namespace: app.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+			labels.AcornAppName: app.Name,
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	app.Status.AcornStatus = map[string]v1.AcornStatus{}
+	for acornName := range app.Status.AppSpec.Acorns {
+		linked := ports.IsLinked(app, acornName)
+		app.Status.AcornStatus[acornName] = v1.AcornStatus{
+			Created: linked,
+			Ready:   linked,
+		}
+	}
+
+	var (
+		failed        []string
+		transitioning = sets.NewString()
+	)
+
+	for _, acorn := range acorns.Items {
+		acornName := acorn.Labels[labels.AcornAcornName]
+		if _, ok := app.Status.AcornStatus[acornName]; !ok {
+			continue
+		}
+
+		ready := acorn.Status.Condition(v1.AppInstanceConditionReady)
+		status := v1.AcornStatus{
+			Created: true,
+			Ready:   acorn.Status.Ready,
+			Stopped: acorn.Status.Stopped,
+			Message: acorn.Status.Columns.Message,
+		}
+		app.Status.AcornStatus[acornName] = status
+
+		switch {
+		case ready.Error:
+			failed = append(failed, fmt.Sprintf("%s: %s", acornName, ready.Message))
+		case !status.Ready && !status.Stopped:
+			transitioning.Insert(acornName + " is not ready")
+		}
+	}
+
+	for acornName, status := range app.Status.AcornStatus {
+		if !status.Created {
+			transitioning.Insert(acornName + " pending create")
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		cond.Error(fmt.Errorf("%s", strings.Join(failed, "; ")))
+	case transitioning.Len() > 0:
+		cond.Unknown(strings.Join(transitioning.List(), "; "))
+	default:
+		cond.Success()
+	}
+
+	resp.Objects(app)
+	return nil
+}
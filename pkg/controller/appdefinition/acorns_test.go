@@ -0,0 +1,12 @@
+package appdefinition
+
+import (
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+)
+
+func TestAcorns(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/acorns", DeploySpec)
+}
@@ -0,0 +1,72 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultCPUUtilization is the target used when scale is a range but no utilization target is set
+const defaultCPUUtilization = 80
+
+func isAutoscaled(appInstance *v1.AppInstance, container v1.Container) bool {
+	return container.Autoscale != nil && !isAutoscaleUnsupported(appInstance, container) &&
+		(appInstance.Spec.Stop == nil || !*appInstance.Spec.Stop)
+}
+
+// isAutoscaleUnsupported returns true if scale is a range but the container can not be autoscaled because its
+// replicas do not share their volumes. The container is run with a single replica instead, which AppStatus reports.
+func isAutoscaleUnsupported(appInstance *v1.AppInstance, container v1.Container) bool {
+	return container.Autoscale != nil && (isStateful(appInstance, container) || isPerReplica(appInstance, container))
+}
+
+func toHorizontalPodAutoscaler(appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) *autoscalingv2.HorizontalPodAutoscaler {
+	var (
+		autoscale = container.Autoscale
+		metrics   []autoscalingv2.MetricSpec
+	)
+
+	if autoscale.CPUUtilization != nil {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceCPU, *autoscale.CPUUtilization))
+	}
+	if autoscale.MemoryUtilization != nil {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceMemory, *autoscale.MemoryUtilization))
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, utilizationMetric(corev1.ResourceCPU, defaultCPUUtilization))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dep.Name,
+			Namespace:   dep.Namespace,
+			Labels:      dep.Labels,
+			Annotations: containerAnnotations(appInstance, container, dep.Name),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       dep.Name,
+			},
+			MinReplicas: autoscale.Min,
+			MaxReplicas: autoscale.Max,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func utilizationMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
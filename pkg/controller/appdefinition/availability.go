@@ -0,0 +1,85 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var spreadTopologyKeys = map[string]string{
+	v1.SpreadAcrossNode: corev1.LabelHostname,
+	v1.SpreadAcrossZone: corev1.LabelTopologyZone,
+}
+
+// isReplicated returns true if the container can run more than one replica, only then is there any
+// availability to protect
+func isReplicated(appInstance *v1.AppInstance, container v1.Container) bool {
+	if isStateful(appInstance, container) || (appInstance.Spec.Stop != nil && *appInstance.Spec.Stop) {
+		return false
+	}
+	if container.Autoscale != nil {
+		return container.Autoscale.Max > 1
+	}
+	return container.Scale != nil && *container.Scale > 1
+}
+
+func getAvailability(container v1.Container) v1.Availability {
+	if container.Availability == nil {
+		return v1.Availability{}
+	}
+	return *container.Availability
+}
+
+func toTopologySpreadConstraints(appInstance *v1.AppInstance, name string, container v1.Container) []corev1.TopologySpreadConstraint {
+	if !isReplicated(appInstance, container) {
+		return nil
+	}
+
+	spreadAcross := getAvailability(container).SpreadAcross
+	if spreadAcross == "" {
+		spreadAcross = v1.SpreadAcrossNode
+	}
+
+	topologyKey, ok := spreadTopologyKeys[spreadAcross]
+	if !ok {
+		return nil
+	}
+
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: selectorMatchLabels(appInstance, name),
+			},
+		},
+	}
+}
+
+func toPodDisruptionBudget(appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dep.Name,
+			Namespace:   dep.Namespace,
+			Labels:      dep.Labels,
+			Annotations: containerAnnotations(appInstance, container, dep.Name),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: dep.Spec.Selector,
+		},
+	}
+
+	if minAvailable := getAvailability(container).MinAvailable; minAvailable != nil {
+		pdb.Spec.MinAvailable = minAvailable
+	} else {
+		// By default only take down one replica at a time
+		maxUnavailable := intstr.FromInt(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+
+	return pdb
+}
@@ -14,6 +14,11 @@ func CLIStatus(req router.Request, resp router.Response) error {
 	app.Status.Columns.UpToDate = uptodate(app)
 	app.Status.Columns.Healthy = healthy(app)
 	app.Status.Columns.Message = message(app)
+	resources, err := resources(req, app)
+	if err != nil {
+		return err
+	}
+	app.Status.Columns.Resources = resources
 	resp.Objects(app)
 	return nil
 }
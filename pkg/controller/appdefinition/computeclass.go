@@ -0,0 +1,76 @@
+package appdefinition
+
+import (
+	"fmt"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// computeClassName returns the class requested for the container, the class set at run time takes precedence
+// over the class in the Acornfile
+func computeClassName(appInstance *v1.AppInstance, name string, container v1.Container) string {
+	if class := appInstance.Spec.ComputeClass.Get(name); class != "" {
+		return class
+	}
+	return container.Class
+}
+
+// computeClasses looks up the compute classes of the containers of an app. Each class, and the list of all classes
+// needed to find the cluster default, is only read once.
+type computeClasses struct {
+	req          router.Request
+	classes      map[string]*v1.ComputeClassInstance
+	defaultClass *v1.ComputeClassInstance
+	listed       bool
+}
+
+func newComputeClasses(req router.Request) *computeClasses {
+	return &computeClasses{
+		req:     req,
+		classes: map[string]*v1.ComputeClassInstance{},
+	}
+}
+
+// get returns the class the container should be scheduled with. If the container does not request a class the
+// cluster default is used. A nil result means no class applies.
+func (c *computeClasses) get(appInstance *v1.AppInstance, name string, container v1.Container) (*v1.ComputeClassInstance, error) {
+	className := computeClassName(appInstance, name, container)
+	if className == "" {
+		if !c.listed {
+			classes := &v1.ComputeClassInstanceList{}
+			if err := c.req.List(classes, &kclient.ListOptions{}); err != nil {
+				return nil, err
+			}
+			c.defaultClass = classes.Default()
+			c.listed = true
+		}
+		return c.defaultClass, nil
+	}
+
+	if class, ok := c.classes[className]; ok {
+		return class, nil
+	}
+
+	class := &v1.ComputeClassInstance{}
+	if err := c.req.Get(class, "", className); apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("compute class [%s] for container [%s] not found", className, name)
+	} else if err != nil {
+		return nil, err
+	}
+	c.classes[className] = class
+	return class, nil
+}
+
+// applyComputeClass sets the scheduling rules of the class on the pod
+func applyComputeClass(podSpec *corev1.PodSpec, class *v1.ComputeClassInstance) {
+	if class == nil {
+		return
+	}
+	podSpec.Affinity = class.Affinity
+	podSpec.Tolerations = class.Tolerations
+	podSpec.PriorityClassName = class.PriorityClassName
+}
@@ -0,0 +1,173 @@
+package appdefinition
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/images"
+	"github.com/acorn-io/baaah/pkg/apply"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	DeleteJobsFinalizer = "jobs.acorn.io/delete"
+
+	// deleteJobsTimeout is how long the removal of an app waits for its delete jobs
+	deleteJobsTimeout = 10 * time.Minute
+	// deleteJobsPollInterval is how often the delete jobs are checked while the removal waits
+	deleteJobsPollInterval = 5 * time.Second
+)
+
+// RunDeleteJobs runs the jobs that have the delete event before the app is removed. The finalizer is only added to
+// apps that declare a delete job and is dropped again when an update removes them. The jobs are created directly
+// instead of through the response so that nothing else of the app is pruned while they run. The finalizer is kept
+// until all jobs have finished, or until deleteJobsTimeout has passed since the app was deleted in which case a
+// warning event is recorded for the app and the jobs that are still running are left to be removed with it.
+func RunDeleteJobs(req router.Request, resp router.Response) error {
+	appInstance := req.Object.(*v1.AppInstance)
+
+	var deleteJobs []string
+	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Jobs) {
+		if hasEvents(entry.Value) && runsOnEvent(entry.Value, v1.JobEventDelete) {
+			deleteJobs = append(deleteJobs, entry.Key)
+		}
+	}
+
+	if appInstance.DeletionTimestamp.IsZero() {
+		if len(deleteJobs) > 0 && !slices.Contains(appInstance.Finalizers, DeleteJobsFinalizer) {
+			appInstance.Finalizers = append(appInstance.Finalizers, DeleteJobsFinalizer)
+			return req.Client.Update(req.Ctx, appInstance)
+		} else if len(deleteJobs) == 0 && slices.Contains(appInstance.Finalizers, DeleteJobsFinalizer) {
+			return removeDeleteJobsFinalizer(req, appInstance)
+		}
+		return nil
+	}
+
+	if !slices.Contains(appInstance.Finalizers, DeleteJobsFinalizer) {
+		return nil
+	}
+
+	running, err := runDeleteJobs(req, appInstance, deleteJobs)
+	if err != nil {
+		return err
+	}
+
+	if len(running) > 0 {
+		if now().Sub(appInstance.DeletionTimestamp.Time) <= deleteJobsTimeout {
+			resp.RetryAfter(deleteJobsPollInterval)
+			return nil
+		}
+		if err := req.Client.Create(req.Ctx, deleteJobsTimeoutEvent(appInstance, running)); err != nil {
+			return err
+		}
+	}
+
+	return removeDeleteJobsFinalizer(req, appInstance)
+}
+
+// runDeleteJobs ensures the delete jobs exist and returns the names of the ones that have not finished yet
+func runDeleteJobs(req router.Request, appInstance *v1.AppInstance, deleteJobs []string) ([]string, error) {
+	if len(deleteJobs) == 0 || appInstance.Status.Namespace == "" {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := req.Get(ns, "", appInstance.Status.Namespace); apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if !ns.DeletionTimestamp.IsZero() {
+		// Too late, nothing can be started in the namespace anymore
+		return nil, nil
+	}
+
+	tag, err := images.GetRuntimePullableImageReference(req.Ctx, req.Client, appInstance.Namespace, appInstance.Status.AppImage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pullSecrets, err := NewPullSecrets(req, appInstance)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		classes = newComputeClasses(req)
+		running []string
+	)
+	for _, jobName := range deleteJobs {
+		job, err := toJob(req, appInstance, pullSecrets, tag, jobName, appInstance.Status.AppSpec.Jobs[jobName], classes)
+		if err != nil {
+			return nil, err
+		}
+		if err := apply.New(req.Client).Ensure(req.Ctx, job); err != nil {
+			return nil, err
+		}
+
+		existing := &batchv1.Job{}
+		if err := req.Get(existing, appInstance.Status.Namespace, jobName); err != nil {
+			return nil, err
+		}
+		if !isJobFinished(existing) {
+			running = append(running, jobName)
+		}
+	}
+
+	return running, nil
+}
+
+func removeDeleteJobsFinalizer(req router.Request, appInstance *v1.AppInstance) error {
+	finalizers := make([]string, 0, len(appInstance.Finalizers))
+	for _, finalizer := range appInstance.Finalizers {
+		if finalizer != DeleteJobsFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	appInstance.Finalizers = finalizers
+	return req.Client.Update(req.Ctx, appInstance)
+}
+
+func deleteJobsTimeoutEvent(appInstance *v1.AppInstance, running []string) *corev1.Event {
+	timestamp := metav1.NewTime(now())
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: appInstance.Name + "-",
+			Namespace:    appInstance.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "AppInstance",
+			Name:       appInstance.Name,
+			Namespace:  appInstance.Namespace,
+			UID:        appInstance.UID,
+		},
+		Type:   corev1.EventTypeWarning,
+		Reason: "DeleteJobsTimeout",
+		Message: fmt.Sprintf("delete jobs [%s] did not finish within %s, the app was removed without waiting for them",
+			strings.Join(running, ", "), deleteJobsTimeout),
+		Source: corev1.EventSource{
+			Component: "acorn-controller",
+		},
+		FirstTimestamp: timestamp,
+		LastTimestamp:  timestamp,
+		Count:          1,
+	}
+}
+
+// isJobFinished returns true if the job succeeded or failed after all retries
+func isJobFinished(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
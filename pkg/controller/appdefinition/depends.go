@@ -118,7 +118,7 @@ func (d *depCheckingResponse) isDepReady(depName string) (ready bool, found bool
 	var depDep appsv1.Deployment
 	err := d.req.Get(&depDep, d.app.Status.Namespace, depName)
 	if apierrors.IsNotFound(err) {
-		return false, false
+		return d.isStatefulSetReady(depName)
 	}
 	if err != nil {
 		// if err just return it as not ready
@@ -169,6 +169,30 @@ func (d *depCheckingResponse) isDepReady(depName string) (ready bool, found bool
 	return false, true
 }
 
+// isStatefulSetReady checks the dependency on a container with per replica volumes, which is run as a StatefulSet
+func (d *depCheckingResponse) isStatefulSetReady(depName string) (ready bool, found bool) {
+	var set appsv1.StatefulSet
+	err := d.req.Get(&set, d.app.Status.Namespace, depName)
+	if apierrors.IsNotFound(err) {
+		return false, false
+	}
+	if err != nil {
+		// if err just return it as not ready
+		return false, true
+	}
+
+	replicas := int32(1)
+	if set.Spec.Replicas != nil {
+		replicas = *set.Spec.Replicas
+	}
+
+	return set.Annotations[labels.AcornAppGeneration] == strconv.Itoa(int(d.app.Generation)) &&
+		set.Status.ObservedGeneration == set.Generation &&
+		set.Status.UpdateRevision == set.Status.CurrentRevision &&
+		set.Status.ReadyReplicas == replicas &&
+		set.Status.UpdatedReplicas == replicas, true
+}
+
 type depCheck func(string) (bool, bool)
 
 func (d *depCheckingResponse) checkDeps(deps []string) bool {
@@ -179,6 +203,10 @@ outer:
 				return true
 			}
 		}
+		if job, ok := d.app.Status.AppSpec.Jobs[depName]; ok && !runsOnEvent(job, jobEvent(d.app)) {
+			// The job does not run on this event so there is nothing to wait for
+			continue
+		}
 		for _, depCheck := range []depCheck{d.isDepReady, d.isJobReady, d.isCronJobReady} {
 			if ready, found := depCheck(depName); found && !ready {
 				return false
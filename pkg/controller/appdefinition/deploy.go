@@ -2,9 +2,9 @@ package appdefinition
 
 import (
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	url2 "net/url"
 	"path"
 	"strings"
@@ -17,12 +17,13 @@ import (
 	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/ports"
 	"github.com/acorn-io/acorn/pkg/system"
-	"github.com/acorn-io/acorn/pkg/tags"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
 	"github.com/acorn-io/baaah/pkg/apply"
 	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/rancher/wrangler/pkg/data/convert"
+	name2 "github.com/rancher/wrangler/pkg/name"
 	"golang.org/x/exp/maps"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -37,10 +38,15 @@ func DeploySpec(req router.Request, resp router.Response) (err error) {
 	status := condition.Setter(appInstance, resp, v1.AppInstanceConditionDefined)
 
 	defer func() {
-		if err == nil {
-			status.Success()
-		} else {
+		if err != nil {
 			status.Error(err)
+		} else if warnings := interpolationWarnings(appInstance); len(warnings) > 0 {
+			status.Set(v1.Condition{
+				Success: true,
+				Message: strings.Join(warnings, ", "),
+			})
+		} else {
+			status.Success()
 		}
 	}()
 
@@ -66,6 +72,7 @@ func DeploySpec(req router.Request, resp router.Response) (err error) {
 	if err := addRouters(appInstance, resp); err != nil {
 		return err
 	}
+	addAcorns(appInstance, tag, resp)
 	if err := addJobs(req, appInstance, tag, pullSecrets, resp); err != nil {
 		return err
 	}
@@ -78,7 +85,7 @@ func DeploySpec(req router.Request, resp router.Response) (err error) {
 	if err := addPVCs(req, appInstance, resp); err != nil {
 		return err
 	}
-	if err := addConfigMaps(appInstance, resp); err != nil {
+	if err := addFiles(appInstance, resp); err != nil {
 		return err
 	}
 
@@ -111,29 +118,13 @@ func toEnvFrom(envs []v1.EnvVar) (result []corev1.EnvFromSource) {
 	return
 }
 
-func translateEnvValue(app *v1.AppInstance, value string) string {
-	switch value {
-	case "${app.image}":
-		if tags.IsLocalReference(app.Status.AppImage.ID) {
-			return app.Status.AppImage.ID
-		} else if app.Status.AppImage.ID != "" && app.Status.AppImage.Digest != "" {
-			tag, err := name.NewTag(app.Status.AppImage.ID)
-			if err == nil {
-				return tag.Digest(app.Status.AppImage.Digest).String()
-			}
-		}
-	case "${app.namespace}":
-		return app.Namespace
-	}
-	return value
-}
-
-func toEnv(app *v1.AppInstance, envs []v1.EnvVar, appEnv []v1.NameValue) (result []corev1.EnvVar) {
+func toEnv(app *v1.AppInstance, containerName string, envs []v1.EnvVar, appEnv []v1.NameValue) (result []corev1.EnvVar) {
+	interpolator := newInterpolator(app, containerName)
 	for _, env := range envs {
 		if env.Secret.Name == "" {
 			result = append(result, corev1.EnvVar{
 				Name:  env.Name,
-				Value: translateEnvValue(app, env.Value),
+				Value: interpolator.env(env.Value),
 			})
 		} else {
 			if env.Secret.Key == "" {
@@ -155,10 +146,10 @@ func toEnv(app *v1.AppInstance, envs []v1.EnvVar, appEnv []v1.NameValue) (result
 	for _, appEnv := range appEnv {
 		result = append(result, corev1.EnvVar{
 			Name:  appEnv.Name,
-			Value: appEnv.Value,
+			Value: interpolator.env(appEnv.Value),
 		})
 	}
-	return
+	return append(interpolator.runtimeEnv(), result...)
 }
 
 func hasContextDir(container v1.Container) bool {
@@ -175,7 +166,7 @@ func hasContextDir(container v1.Container) bool {
 	return false
 }
 
-func toContainers(app *v1.AppInstance, tag name.Reference, name string, container v1.Container) ([]corev1.Container, []corev1.Container) {
+func toContainers(app *v1.AppInstance, tag name.Reference, name string, container v1.Container, class *v1.ComputeClassInstance) ([]corev1.Container, []corev1.Container, error) {
 	var (
 		containers     []corev1.Container
 		initContainers []corev1.Container
@@ -196,9 +187,18 @@ func toContainers(app *v1.AppInstance, tag name.Reference, name string, containe
 		})
 	}
 
-	containers = append(containers, toContainer(app, tag, name, name, container))
+	initContainers = append(initContainers, toSeedContainers(app, tag, container)...)
+
+	newContainer, err := toContainer(app, tag, name, name, container, class)
+	if err != nil {
+		return nil, nil, err
+	}
+	containers = append(containers, newContainer)
 	for _, entry := range typed.Sorted(container.Sidecars) {
-		newContainer := toContainer(app, tag, name, entry.Key, entry.Value)
+		newContainer, err := toContainer(app, tag, name, entry.Key, entry.Value, class)
+		if err != nil {
+			return nil, nil, err
+		}
 		if entry.Value.Init {
 			initContainers = append(initContainers, newContainer)
 		} else {
@@ -206,7 +206,47 @@ func toContainers(app *v1.AppInstance, tag name.Reference, name string, containe
 		}
 	}
 
-	return containers, initContainers
+	return containers, initContainers, nil
+}
+
+// toSeedContainers returns the init containers that copy the seeds into the volumes of the container. The seed images
+// can not be expected to have any tools, so the acorn binary is copied into a shared volume first and runs the copy.
+// The copy only happens for an empty volume, so the init containers are kept for seeded volumes and the pod template
+// does not change once a volume is seeded.
+func toSeedContainers(app *v1.AppInstance, tag name.Reference, container v1.Container) (result []corev1.Container) {
+	volumes := seededVolumes(app, container)
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	binMount := corev1.VolumeMount{
+		Name:      sanitizeVolumeName(AcornSeed),
+		MountPath: volumebackup.SeedBinPath,
+	}
+	result = append(result, corev1.Container{
+		Name:            "acorn-seed",
+		Image:           system.DefaultImage(),
+		Command:         []string{"cp", "-f", "/usr/local/bin/acorn", path.Join(volumebackup.SeedBinPath, "acorn")},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		VolumeMounts:    []corev1.VolumeMount{binMount},
+	})
+
+	for _, volume := range volumes {
+		seed := app.Status.AppSpec.Volumes[volume].Seed
+		result = append(result, corev1.Container{
+			Name:    name2.SafeConcatName("acorn-seed", volume),
+			Image:   images.ResolveTag(tag, seed.Image),
+			Command: []string{path.Join(volumebackup.SeedBinPath, "acorn"), "volume-helper", "seed", seed.Path, volumebackup.SeedDataPath},
+			VolumeMounts: []corev1.VolumeMount{
+				binMount,
+				{
+					Name:      sanitizeVolumeName(volume),
+					MountPath: volumebackup.SeedDataPath,
+				},
+			},
+		})
+	}
+	return result
 }
 
 func pathHash(parts ...string) string {
@@ -336,6 +376,17 @@ func toProbeHandler(probe v1.Probe) corev1.ProbeHandler {
 			ph.TCPSocket = socket
 		}
 	}
+	if probe.GRPC != nil {
+		// The URL is validated when the app is parsed
+		if port, err := probe.GRPC.Port(); err == nil {
+			ph.GRPC = &corev1.GRPCAction{
+				Port: port,
+			}
+			if probe.GRPC.Service != "" {
+				ph.GRPC.Service = &probe.GRPC.Service
+			}
+		}
+	}
 	if probe.Exec != nil {
 		ph.Exec = &corev1.ExecAction{
 			Command: probe.Exec.Command,
@@ -390,23 +441,30 @@ func toProbe(container v1.Container, probeType v1.ProbeType) *corev1.Probe {
 	return nil
 }
 
-func toContainer(app *v1.AppInstance, tag name.Reference, deploymentName, containerName string, container v1.Container) corev1.Container {
-	return corev1.Container{
-		Name:           containerName,
-		Image:          images.ResolveTag(tag, container.Image),
-		Command:        container.Entrypoint,
-		Args:           container.Command,
-		WorkingDir:     container.WorkingDir,
-		Env:            toEnv(app, container.Environment, app.Spec.Environment),
-		EnvFrom:        toEnvFrom(container.Environment),
-		TTY:            container.Interactive,
-		Stdin:          container.Interactive,
-		Ports:          toPorts(container),
-		VolumeMounts:   toMounts(app, deploymentName, containerName, container),
-		LivenessProbe:  toProbe(container, v1.LivenessProbeType),
-		StartupProbe:   toProbe(container, v1.StartupProbeType),
-		ReadinessProbe: toProbe(container, v1.ReadinessProbeType),
+func toContainer(app *v1.AppInstance, tag name.Reference, deploymentName, containerName string, container v1.Container, class *v1.ComputeClassInstance) (corev1.Container, error) {
+	reqs, err := toResources(app, containerName, container, class)
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("container [%s]: %w", containerName, err)
 	}
+	return corev1.Container{
+		Name:            containerName,
+		Image:           images.ResolveTag(tag, container.Image),
+		Command:         container.Entrypoint,
+		Args:            container.Command,
+		WorkingDir:      container.WorkingDir,
+		Env:             toEnv(app, containerName, container.Environment, app.Spec.Environment),
+		EnvFrom:         toEnvFrom(container.Environment),
+		TTY:             container.Interactive,
+		Stdin:           container.Interactive,
+		Ports:           toPorts(container),
+		VolumeMounts:    toMounts(app, deploymentName, containerName, container),
+		LivenessProbe:   toProbe(container, v1.LivenessProbeType),
+		StartupProbe:    toProbe(container, v1.StartupProbeType),
+		ReadinessProbe:  toProbe(container, v1.ReadinessProbeType),
+		Resources:       reqs,
+		SecurityContext: toSecurityContext(app, deploymentName, container),
+		Lifecycle:       toLifecycle(container),
+	}, nil
 }
 
 func containerAnnotations(appInstance *v1.AppInstance, container v1.Container, name string) map[string]string {
@@ -495,7 +553,7 @@ func isStateful(appInstance *v1.AppInstance, container v1.Container) bool {
 			continue
 		}
 		for volName, vol := range appInstance.Status.AppSpec.Volumes {
-			if vol.Class == "ephemeral" {
+			if vol.Class == "ephemeral" || isPerReplicaVolume(appInstance, volName) {
 				continue
 			}
 			if dir.Volume == volName {
@@ -513,8 +571,13 @@ func getRevision(req router.Request, namespace, secretName string) (string, erro
 	if err := req.Get(secret, namespace, secretName); err != nil {
 		return "0", err
 	}
+	_, rotated := secret.Annotations[labels.AcornSecretRotations]
 	hash := sha256.New()
 	for _, entry := range typed.Sorted(secret.Data) {
+		if rotated && strings.HasPrefix(entry.Key, previousKeyPrefix) {
+			// Dropping the values from before a rotation does not need a redeploy
+			continue
+		}
 		hash.Write([]byte(entry.Key))
 		hash.Write([]byte{'\x00'})
 		hash.Write(entry.Value)
@@ -534,6 +597,11 @@ func getSecretAnnotations(req router.Request, appInstance *v1.AppInstance, conta
 		if env.Secret.OnChange == v1.ChangeTypeRedeploy {
 			secrets = append(secrets, env.Secret.Name)
 		}
+		for _, secret := range inlineSecrets(env.Value) {
+			if secret.OnChange == v1.ChangeTypeRedeploy {
+				secrets = append(secrets, secret.Name)
+			}
+		}
 	}
 	for _, file := range container.Files {
 		if file.Secret.OnChange == v1.ChangeTypeRedeploy {
@@ -563,12 +631,20 @@ func getSecretAnnotations(req router.Request, appInstance *v1.AppInstance, conta
 	return result, nil
 }
 
-func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Reference, name string, container v1.Container, pullSecrets *PullSecrets) (*appsv1.Deployment, error) {
+func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Reference, name string, container v1.Container, pullSecrets *PullSecrets, classes *computeClasses) (*appsv1.Deployment, error) {
 	var (
 		stateful = isStateful(appInstance, container)
 	)
 
-	containers, initContainers := toContainers(appInstance, tag, name, container)
+	class, err := classes.get(appInstance, name, container)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, initContainers, err := toContainers(appInstance, tag, name, container, class)
+	if err != nil {
+		return nil, err
+	}
 
 	secretAnnotations, err := getSecretAnnotations(req, appInstance, container)
 	if err != nil {
@@ -580,6 +656,11 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 		return nil, err
 	}
 
+	terminationGracePeriod, err := toTerminationGracePeriod(name, container)
+	if err != nil {
+		return nil, err
+	}
+
 	podLabels := containerLabels(appInstance, container, name)
 	deploymentLabels := containerLabels(appInstance, container, name)
 	matchLabels := selectorMatchLabels(appInstance, name)
@@ -605,18 +686,22 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 					Annotations: typed.Concat(deploymentAnnotations, podAnnotations(appInstance, name, container), secretAnnotations),
 				},
 				Spec: corev1.PodSpec{
-					TerminationGracePeriodSeconds: &[]int64{5}[0],
+					TerminationGracePeriodSeconds: terminationGracePeriod,
 					ImagePullSecrets:              pullSecrets.ForContainer(name, append(containers, initContainers...)),
 					EnableServiceLinks:            new(bool),
 					Containers:                    containers,
 					InitContainers:                initContainers,
 					Volumes:                       volumes,
 					ServiceAccountName:            name,
+					TopologySpreadConstraints:     toTopologySpreadConstraints(appInstance, name, container),
 				},
 			},
 		},
 	}
 
+	applyComputeClass(&dep.Spec.Template.Spec, class)
+	applyHostNamespaces(&dep.Spec.Template.Spec, appInstance, name, container)
+
 	if stateful {
 		dep.Spec.Replicas = &[]int32{1}[0]
 		dep.Spec.Template.Spec.Hostname = dep.Name
@@ -625,6 +710,15 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 		dep.Spec.Template.Spec.Hostname = dep.Name
 	}
 
+	if err := applyRollout(dep, container); err != nil {
+		return nil, err
+	}
+
+	if isAutoscaled(appInstance, container) {
+		// The HorizontalPodAutoscaler owns the replica count
+		dep.Spec.Replicas = nil
+	}
+
 	if appInstance.Spec.Stop != nil && *appInstance.Spec.Stop {
 		dep.Spec.Replicas = new(int32)
 	}
@@ -633,11 +727,12 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 }
 
 func ToDeployments(req router.Request, appInstance *v1.AppInstance, tag name.Reference, pullSecrets *PullSecrets) (result []kclient.Object, _ error) {
+	classes := newComputeClasses(req)
 	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Containers) {
 		if ports.IsLinked(appInstance, entry.Key) {
 			continue
 		}
-		dep, err := toDeployment(req, appInstance, tag, entry.Key, entry.Value, pullSecrets)
+		dep, err := toDeployment(req, appInstance, tag, entry.Key, entry.Value, pullSecrets, classes)
 		if err != nil {
 			return nil, err
 		}
@@ -645,7 +740,28 @@ func ToDeployments(req router.Request, appInstance *v1.AppInstance, tag name.Ref
 		if perms := v1.FindPermission(dep.GetName(), appInstance.Spec.Permissions); perms.HasRules() {
 			result = append(result, toPermissions(perms, dep.GetLabels(), dep.GetAnnotations(), appInstance)...)
 		}
-		result = append(result, dep, sa)
+		if isPerReplica(appInstance, entry.Value) {
+			sts := toStatefulSet(appInstance, dep, entry.Value)
+			hold, err := holdStatefulSet(req, appInstance, sts, entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			if hold {
+				sts.Annotations = typed.Concat(sts.Annotations, map[string]string{
+					apply.AnnotationCreate: "false",
+					apply.AnnotationUpdate: "false",
+				})
+			}
+			result = append(result, sts, toHeadlessService(dep), sa)
+		} else {
+			result = append(result, dep, sa)
+		}
+		if isAutoscaled(appInstance, entry.Value) {
+			result = append(result, toHorizontalPodAutoscaler(appInstance, dep, entry.Value))
+		}
+		if isReplicated(appInstance, entry.Value) {
+			result = append(result, toPodDisruptionBudget(appInstance, dep, entry.Value))
+		}
 	}
 	return result, nil
 }
@@ -673,73 +789,19 @@ func addNamespace(cfg *apiv1.Config, appInstance *v1.AppInstance, resp router.Re
 	})
 }
 
-func addFileContent(configMap *corev1.ConfigMap, appName, deploymentName string, container v1.Container) error {
-	data := configMap.BinaryData
-	for filePath, file := range container.Files {
-		content, err := base64.StdEncoding.DecodeString(file.Content)
-		if err != nil {
-			return err
-		}
-		hashPath := pathHash(appName, deploymentName, deploymentName, filePath)
-		data[hashPath] = content
-		configMap.Annotations[hashPath] = path.Join(appName, deploymentName, deploymentName, filePath)
-	}
-	for sidecarName, sidecar := range container.Sidecars {
-		for filePath, file := range sidecar.Files {
-			content, err := base64.StdEncoding.DecodeString(file.Content)
-			if err != nil {
-				return err
-			}
-			hashPath := pathHash(appName, deploymentName, sidecarName, filePath)
-			data[hashPath] = content
-			configMap.Annotations[hashPath] = path.Join(appName, deploymentName, sidecarName, filePath)
-		}
-	}
-	return nil
-}
-
-func addConfigMaps(appInstance *v1.AppInstance, resp router.Response) error {
-	objs, err := toConfigMaps(appInstance)
+func addFiles(appInstance *v1.AppInstance, resp router.Response) error {
+	objs, err := toFiles(appInstance)
 	resp.Objects(objs...)
 	return err
 }
 
-func toConfigMaps(appInstance *v1.AppInstance) (result []kclient.Object, err error) {
-	configMap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "files",
-			Namespace: appInstance.Status.Namespace,
-			Labels: map[string]string{
-				labels.AcornManaged: "true",
-			},
-			Annotations: map[string]string{},
-		},
-		BinaryData: map[string][]byte{},
-	}
-	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Containers) {
-		if err := addFileContent(configMap, appInstance.Name, entry.Key, entry.Value); err != nil {
-			return nil, err
-		}
-	}
-	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Jobs) {
-		if err := addFileContent(configMap, appInstance.Name, entry.Key, entry.Value); err != nil {
-			return nil, err
-		}
-	}
-	if len(configMap.BinaryData) == 0 {
-		return nil, nil
+func toFiles(appInstance *v1.AppInstance) (result []kclient.Object, err error) {
+	objects, err := toFileObjects(appInstance)
+	if err != nil {
+		return nil, err
 	}
-
-	fileMode := getFilesFileModesForApp(appInstance)
-	for _, mode := range typed.SortedKeys(fileMode) {
-		if mode == "" {
-			result = append(result, configMap)
-		} else {
-			copy := configMap.DeepCopy()
-			copy.Name += "-" + mode
-			result = append(result, copy)
-		}
+	for _, obj := range objects {
+		result = append(result, obj.toObject(appInstance.Status.Namespace))
 	}
-
 	return result, nil
 }
@@ -1,6 +1,7 @@
 package appdefinition
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"strings"
 	"testing"
@@ -30,6 +31,62 @@ func TestGlobalEnv(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/globalenv", DeploySpec)
 }
 
+func TestInterpolation(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/interpolation", DeploySpec)
+}
+
+func TestInterpolationPending(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/interpolation-pending", DeploySpec)
+}
+
+func TestInterpolationWarnings(t *testing.T) {
+	app := &v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"web": {
+						Environment: []v1.EnvVar{
+							{
+								Name:  "DATABASE_URL",
+								Value: "postgres://${service.db}:5432/app",
+							},
+							{
+								Name:  "PUBLIC_URL",
+								Value: "https://${endpoint.web}",
+							},
+						},
+						Files: v1.Files{
+							// Only template files are interpolated
+							"/etc/application.properties": {
+								Content: base64.StdEncoding.EncodeToString([]byte("url=${service.url}")),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{
+		"unknown service references ${service.db}",
+		"waiting for endpoints of web",
+	}, interpolationWarnings(app))
+
+	app.Spec.Links = []v1.ServiceBinding{
+		{
+			Target:  "db",
+			Service: "postgres",
+		},
+	}
+	app.Status.Endpoints = []v1.Endpoint{
+		{
+			Target:   "web",
+			Address:  "web.example.com",
+			Protocol: v1.ProtocolHTTP,
+		},
+	}
+	assert.Empty(t, interpolationWarnings(app))
+}
+
 func TestDeploySpec(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/deployspec", DeploySpec)
 }
@@ -42,6 +99,59 @@ func TestProbe(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/probes", DeploySpec)
 }
 
+func TestResources(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/resources", DeploySpec)
+}
+
+func TestInvalidResources(t *testing.T) {
+	appInstance := &v1.AppInstance{
+		Spec: v1.AppInstanceSpec{
+			Memory: v1.ResourceMap{"test": {Request: "lots", Limit: "lots"}},
+		},
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {},
+				},
+			},
+		},
+	}
+	_, err := ToDeployments(tester.NewRequest(t, scheme.Scheme, appInstance), appInstance, testTag, nil)
+	assert.ErrorContains(t, err, "container [test]: invalid memory request [lots]")
+}
+
+func TestAutoscale(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/autoscale", DeploySpec)
+}
+
+func TestAutoscaleStateful(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/autoscale-stateful", AppStatus)
+}
+
+func TestAvailability(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/availability", DeploySpec)
+}
+
+func TestSecurity(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/security", DeploySpec)
+}
+
+func TestLifecycle(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/lifecycle", DeploySpec)
+}
+
+func TestRollout(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/rollout/basic", DeploySpec)
+}
+
+func TestRolloutStalled(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/rollout/stalled", AppStatus)
+}
+
+func TestComputeClass(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/computeclass", DeploySpec)
+}
+
 func ToDeploymentsTest(t *testing.T, appInstance *v1.AppInstance, tag name.Reference, pullSecrets *PullSecrets) (result []kclient.Object) {
 	t.Helper()
 
@@ -259,7 +369,7 @@ func TestFiles(t *testing.T) {
 	assert.Equal(t, "/a/b2/c", dep.Spec.Template.Spec.Containers[1].VolumeMounts[1].MountPath)
 	assert.Equal(t, toPathHash("/app/test/left/a/b2/c"), dep.Spec.Template.Spec.Containers[1].VolumeMounts[1].SubPath)
 
-	configMaps, err := toConfigMaps(app)
+	configMaps, err := toFiles(app)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -276,6 +386,71 @@ func TestFiles(t *testing.T) {
 	assert.Equal(t, []byte("e"), configMap.BinaryData[toPathHash("/app/test2/left/a/b1/c")])
 }
 
+func TestFileShards(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString(make([]byte, 400_000))
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-namespace",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						Files: map[string]v1.File{
+							"/a":    {Content: content},
+							"/b":    {Content: content},
+							"/c":    {Content: content},
+							"/key":  {Content: "a2V5", Mode: "0400", Sensitive: true},
+							"/key2": {Content: "a2V5", Mode: "0400"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objs, err := toFiles(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, objs, 4) {
+		return
+	}
+
+	assert.Equal(t, "files", objs[0].GetName())
+	assert.Len(t, objs[0].(*corev1.ConfigMap).BinaryData, 2)
+	assert.Equal(t, "files-1", objs[1].GetName())
+	assert.Len(t, objs[1].(*corev1.ConfigMap).BinaryData, 1)
+	assert.Equal(t, "files-secret-0400", objs[2].GetName())
+	assert.Equal(t, []byte("key"), objs[2].(*corev1.Secret).Data[toPathHash("/app/test/test/key")])
+	assert.Equal(t, "files-0400", objs[3].GetName())
+	assert.Equal(t, []byte("key"), objs[3].(*corev1.ConfigMap).BinaryData[toPathHash("/app/test/test/key2")])
+
+	dep := ToDeploymentsTest(t, app, testTag, nil)[0].(*appsv1.Deployment)
+	volumes := dep.Spec.Template.Spec.Volumes
+	if !assert.Len(t, volumes, 2) {
+		return
+	}
+	assert.Equal(t, "files", volumes[0].Name)
+	assert.Equal(t, []corev1.VolumeProjection{
+		{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "files"}}},
+		{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "files-1"}}},
+	}, volumes[0].Projected.Sources)
+	assert.Equal(t, "files-0400", volumes[1].Name)
+	assert.Equal(t, int32(0400), *volumes[1].Projected.DefaultMode)
+	assert.Equal(t, []corev1.VolumeProjection{
+		{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "files-secret-0400"}}},
+		{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "files-0400"}}},
+	}, volumes[1].Projected.Sources)
+
+	app.Status.AppSpec.Containers["test"].Files["/a"] = v1.File{
+		Content: base64.StdEncoding.EncodeToString(make([]byte, v1.MaxFileSize+1)),
+	}
+	_, err = toFiles(app)
+	assert.EqualError(t, err, "file /a of container test is 1048565 bytes after interpolation, files can not be larger than 1048564 bytes")
+}
+
 func toPathHash(path string) string {
 	path = strings.TrimPrefix(path, "/")
 	return hex.EncodeToString(sha256.Sum256([]byte(path))[:])[:12]
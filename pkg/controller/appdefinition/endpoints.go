@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
 	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/publish"
 	"github.com/acorn-io/baaah/pkg/router"
@@ -111,11 +112,16 @@ func ingressEndpoints(req router.Request, app *v1.AppInstance) (endpoints []v1.E
 				hostname = hostnameOverride
 			}
 
+			protocol := target.Protocol
+			if protocol == "" {
+				protocol = v1.ProtocolHTTP
+			}
+
 			endpoints = append(endpoints, v1.Endpoint{
 				Target:     target.Service,
 				TargetPort: target.Port,
 				Address:    hostname,
-				Protocol:   v1.ProtocolHTTP,
+				Protocol:   protocol,
 				Pending:    len(ingress.Status.LoadBalancer.Ingress) == 0,
 			})
 		}
@@ -124,10 +130,70 @@ func ingressEndpoints(req router.Request, app *v1.AppInstance) (endpoints []v1.E
 	return
 }
 
+// httpRouteEndpoints returns the endpoints of the apps published through a gateway. An endpoint is pending until the
+// gateway accepted its route.
+func httpRouteEndpoints(req router.Request, app *v1.AppInstance) (endpoints []v1.Endpoint, _ error) {
+	routes := publish.NewHTTPRouteList()
+	err := req.List(routes, &kclient.ListOptions{
+		Namespace: app.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		targetStr := route.GetAnnotations()[labels.AcornTargets]
+		if targetStr == "" {
+			continue
+		}
+
+		targets := map[string]publish.Target{}
+		if err := json.Unmarshal([]byte(targetStr), &targets); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range typed.Sorted(targets) {
+			hostname, target := entry.Key, entry.Value
+			if hostnameOverride := route.GetAnnotations()[labels.AcornPublishURL]; hostnameOverride != "" {
+				hostname = hostnameOverride
+			}
+
+			protocol := target.Protocol
+			if protocol == "" {
+				protocol = v1.ProtocolHTTP
+			}
+
+			endpoints = append(endpoints, v1.Endpoint{
+				Target:     target.Service,
+				TargetPort: target.Port,
+				Address:    hostname,
+				Protocol:   protocol,
+				Pending:    !publish.HTTPRouteAccepted(route),
+			})
+		}
+	}
+
+	return
+}
+
 func AppEndpointsStatus(req router.Request, _ router.Response) error {
 	app := req.Object.(*v1.AppInstance)
 
-	ingressEndpoints, err := ingressEndpoints(req, app)
+	cfg, err := config.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	var httpEndpoints []v1.Endpoint
+	if _, _, ok := publish.GatewayRef(cfg); ok {
+		httpEndpoints, err = httpRouteEndpoints(req, app)
+	} else {
+		httpEndpoints, err = ingressEndpoints(req, app)
+	}
 	if err != nil {
 		return err
 	}
@@ -137,22 +203,16 @@ func AppEndpointsStatus(req router.Request, _ router.Response) error {
 		return err
 	}
 
-	eps := append(ingressEndpoints, serviceEndpoints...)
+	eps := append(httpEndpoints, serviceEndpoints...)
 
-	ingressTLSHosts, err := IngressTLSHosts(req.Ctx, req.Client, app)
+	ingressTLSHosts, err := IngressTLSHosts(req.Ctx, req.Client, cfg, app)
 	if err != nil {
 		return err
 	}
 
 	for i, ep := range eps {
-		if ep.Protocol == v1.ProtocolHTTP {
-			ep.PublishProtocol = v1.PublishProtocolHTTP
-			if _, ok := ingressTLSHosts[strings.Split(ep.Address, ":")[0]]; ok {
-				ep.PublishProtocol = v1.PublishProtocolHTTPS
-			}
-		} else {
-			ep.PublishProtocol = v1.PublishProtocol(ep.Protocol)
-		}
+		_, tls := ingressTLSHosts[strings.Split(ep.Address, ":")[0]]
+		ep.PublishProtocol = ep.Protocol.PublishProtocol(ep.Protocol.IsHTTP() && tls)
 		eps[i] = ep
 	}
 
@@ -0,0 +1,247 @@
+package appdefinition
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strconv"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/typed"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fileObject is a ConfigMap, or a Secret for sensitive files, that stores the content of files with the same mode.
+// Files of the same mode and sensitivity are split into as many objects as needed to stay below the size limit of
+// ConfigMaps and Secrets.
+type fileObject struct {
+	name        string
+	mode        string
+	sensitive   bool
+	size        int
+	data        map[string][]byte
+	annotations map[string]string
+}
+
+// fileObjectName returns files[-secret][-MODE] for the first object of a group and adds -N for the following objects
+func fileObjectName(mode string, sensitive bool, index int) string {
+	name := "files"
+	if sensitive {
+		name += "-secret"
+	}
+	if mode != "" {
+		name += "-" + mode
+	}
+	if index > 0 {
+		name += "-" + strconv.Itoa(index)
+	}
+	return name
+}
+
+func (f *fileObject) toObject(namespace string) kclient.Object {
+	meta := metav1.ObjectMeta{
+		Name:      f.name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			labels.AcornManaged: "true",
+		},
+		Annotations: f.annotations,
+	}
+	if f.sensitive {
+		return &corev1.Secret{
+			ObjectMeta: meta,
+			Data:       f.data,
+		}
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: meta,
+		BinaryData: f.data,
+	}
+}
+
+type fileObjectKey struct {
+	mode      string
+	sensitive bool
+}
+
+type fileObjects struct {
+	app     *v1.AppInstance
+	objects []*fileObject
+	current map[fileObjectKey]*fileObject
+	counts  map[fileObjectKey]int
+	// shared has all files that are not sensitive, including empty keys for files from secrets, and the modes
+	// of the ones with content
+	shared      *fileObject
+	sharedModes map[string]bool
+}
+
+// toFileObjects returns the objects storing the content of the files of all containers and jobs of the app, in a
+// stable order so that files only move between objects when the content changes.
+func toFileObjects(app *v1.AppInstance) ([]*fileObject, error) {
+	f := &fileObjects{
+		app:     app,
+		current: map[fileObjectKey]*fileObject{},
+		counts:  map[fileObjectKey]int{},
+		shared: &fileObject{
+			data:        map[string][]byte{},
+			annotations: map[string]string{},
+		},
+		sharedModes: map[string]bool{},
+	}
+	for _, entry := range typed.Sorted(app.Status.AppSpec.Containers) {
+		if err := f.addContainer(entry.Key, entry.Value); err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range typed.Sorted(app.Status.AppSpec.Jobs) {
+		if err := f.addContainer(entry.Key, entry.Value); err != nil {
+			return nil, err
+		}
+	}
+	if f.shared.size <= corev1.MaxSecretSize {
+		return f.sharedObjects(), nil
+	}
+	return f.objects, nil
+}
+
+// sharedObjects returns a ConfigMap with all files that are not sensitive for each mode, followed by the Secrets of
+// the sensitive files. This is how files were stored before they were split by mode, so apps whose files fit in a
+// single ConfigMap keep the same ConfigMaps.
+func (f *fileObjects) sharedObjects() (result []*fileObject) {
+	for _, mode := range typed.SortedKeys(f.sharedModes) {
+		result = append(result, &fileObject{
+			name:        fileObjectName(mode, false, 0),
+			mode:        mode,
+			size:        f.shared.size,
+			data:        f.shared.data,
+			annotations: f.shared.annotations,
+		})
+	}
+	for _, obj := range f.objects {
+		if obj.sensitive {
+			result = append(result, obj)
+		}
+	}
+	return
+}
+
+func (f *fileObjects) addContainer(deploymentName string, container v1.Container) error {
+	if err := f.addFiles(deploymentName, deploymentName, container.Files); err != nil {
+		return err
+	}
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		if err := f.addFiles(deploymentName, entry.Key, entry.Value.Files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fileObjects) addFiles(deploymentName, containerName string, files v1.Files) error {
+	for _, entry := range typed.Sorted(files) {
+		filePath, file := entry.Key, entry.Value
+		hashPath := pathHash(f.app.Name, deploymentName, containerName, filePath)
+		annotation := path.Join(f.app.Name, deploymentName, containerName, filePath)
+		if file.Secret.Name != "" {
+			f.shared.add(hashPath, annotation, []byte{})
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return err
+		}
+		if file.Interpolate {
+			content = newInterpolator(f.app, containerName).file(content)
+		}
+
+		if len(content) > v1.MaxFileSize {
+			return fmt.Errorf("file %s of container %s is %d bytes after interpolation, files can not be larger than %d bytes",
+				filePath, containerName, len(content), v1.MaxFileSize)
+		}
+
+		if !file.Sensitive {
+			f.shared.add(hashPath, annotation, content)
+			if len(content) > 0 {
+				f.sharedModes[normalizeMode(file.Mode)] = true
+			}
+		}
+
+		size := len(hashPath) + len(content)
+		key := fileObjectKey{
+			mode:      normalizeMode(file.Mode),
+			sensitive: file.Sensitive,
+		}
+		obj := f.current[key]
+		if obj == nil || obj.size+size > corev1.MaxSecretSize {
+			obj = &fileObject{
+				name:        fileObjectName(key.mode, key.sensitive, f.counts[key]),
+				mode:        key.mode,
+				sensitive:   key.sensitive,
+				data:        map[string][]byte{},
+				annotations: map[string]string{},
+			}
+			f.counts[key]++
+			f.current[key] = obj
+			f.objects = append(f.objects, obj)
+		}
+
+		obj.add(hashPath, annotation, content)
+	}
+	return nil
+}
+
+func (f *fileObject) add(hashPath, annotation string, content []byte) {
+	f.size += len(hashPath) + len(content)
+	f.data[hashPath] = content
+	f.annotations[hashPath] = annotation
+}
+
+// toFilesVolume returns the volume for the files of the mode. A single ConfigMap is mounted directly, multiple
+// ConfigMaps and Secrets are combined in a projected volume.
+func toFilesVolume(name string, mode *int32, objects []*fileObject) corev1.Volume {
+	if len(objects) == 1 && !objects[0].sensitive {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: mode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: objects[0].name,
+					},
+				},
+			},
+		}
+	}
+
+	projected := &corev1.ProjectedVolumeSource{
+		DefaultMode: mode,
+	}
+	for _, obj := range objects {
+		if obj.sensitive {
+			projected.Sources = append(projected.Sources, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: obj.name,
+					},
+				},
+			})
+		} else {
+			projected.Sources = append(projected.Sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: obj.name,
+					},
+				},
+			})
+		}
+	}
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Projected: projected,
+		},
+	}
+}
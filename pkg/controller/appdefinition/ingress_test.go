@@ -65,3 +65,6 @@ func TestIngressLabels(t *testing.T) {
 func TestLetsEncrypt(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/ingress/letsencrypt", DeploySpec)
 }
+func TestIngressGRPC(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/ingress/grpc", DeploySpec)
+}
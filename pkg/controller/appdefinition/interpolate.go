@@ -0,0 +1,263 @@
+package appdefinition
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/tags"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var interpolationRegexp = regexp.MustCompile(`\${(secret://[^}]+|[a-z]+\.[^}]+)}`)
+
+// interpolator replaces the ${...} references in env values and in the content of files that are given as template.
+// References to values that are known when the app is deployed are replaced directly. References to values that are
+// only known in the pod, which are the pod and node name and secret keys, are replaced with $(NAME) references to env
+// vars that kubernetes expands. Unknown references are left as they are.
+type interpolator struct {
+	app           *v1.AppInstance
+	containerName string
+	runtime       map[string]corev1.EnvVar
+}
+
+func newInterpolator(app *v1.AppInstance, containerName string) *interpolator {
+	return &interpolator{
+		app:           app,
+		containerName: containerName,
+		runtime:       map[string]corev1.EnvVar{},
+	}
+}
+
+// env interpolates an env value
+func (i *interpolator) env(value string) string {
+	return interpolationRegexp.ReplaceAllStringFunc(value, func(ref string) string {
+		key := interpolationRegexp.FindStringSubmatch(ref)[1]
+		if v, ok := i.staticValue(key); ok {
+			return v
+		}
+		if env, ok := i.runtimeRef(key); ok {
+			i.runtime[env.Name] = env
+			return "$(" + env.Name + ")"
+		}
+		return ref
+	})
+}
+
+// file interpolates file content. File content is stored in a ConfigMap, so only the values known when the app is
+// deployed are available.
+func (i *interpolator) file(content []byte) []byte {
+	if !interpolationRegexp.Match(content) {
+		return content
+	}
+	return interpolationRegexp.ReplaceAllFunc(content, func(ref []byte) []byte {
+		key := interpolationRegexp.FindSubmatch(ref)[1]
+		if v, ok := i.staticValue(string(key)); ok {
+			return []byte(v)
+		}
+		return ref
+	})
+}
+
+// runtimeEnv returns the env vars that the interpolated env values reference. They must come before the env vars
+// that reference them.
+func (i *interpolator) runtimeEnv() (result []corev1.EnvVar) {
+	for _, name := range typed.SortedKeys(i.runtime) {
+		result = append(result, i.runtime[name])
+	}
+	return
+}
+
+func (i *interpolator) staticValue(key string) (string, bool) {
+	kind, field, _ := strings.Cut(key, ".")
+	switch kind {
+	case "app":
+		switch field {
+		case "name":
+			return i.app.Name, true
+		case "namespace":
+			return i.app.Namespace, true
+		case "image":
+			return appImage(i.app)
+		}
+	case "container":
+		if field == "name" {
+			return i.containerName, true
+		}
+	case "endpoint":
+		return endpointAddress(i.app, field)
+	case "service":
+		if isAppService(i.app, field) {
+			return field + "." + i.app.Status.Namespace + ".svc", true
+		}
+	}
+	return "", false
+}
+
+func (i *interpolator) runtimeRef(key string) (corev1.EnvVar, bool) {
+	switch key {
+	case "pod.name":
+		return fieldRefEnv("ACORN_POD_NAME", "metadata.name"), true
+	case "node.name":
+		return fieldRefEnv("ACORN_NODE_NAME", "spec.nodeName"), true
+	}
+
+	secret, ok, err := v1.ParseSecretReference(key)
+	if err != nil || !ok || secret.Name == "" || secret.Key == "" {
+		return corev1.EnvVar{}, false
+	}
+	return corev1.EnvVar{
+		Name: "ACORN_SECRET_" + secret.Name + "_" + secret.Key,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret.Name,
+				},
+				Key: secret.Key,
+			},
+		},
+	}, true
+}
+
+// referenceKeys returns the keys of the references in value
+func referenceKeys(value string) (result []string) {
+	for _, match := range interpolationRegexp.FindAllStringSubmatch(value, -1) {
+		result = append(result, match[1])
+	}
+	return
+}
+
+// containerReferenceKeys returns the keys of the references in the env values and template files of the container
+// and its sidecars, including the env of the app that is added to every container
+func containerReferenceKeys(app *v1.AppInstance, container v1.Container) (result []string) {
+	containers := []v1.Container{container}
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		containers = append(containers, entry.Value)
+	}
+	for _, c := range containers {
+		for _, env := range c.Environment {
+			if env.Secret.Name == "" {
+				result = append(result, referenceKeys(env.Value)...)
+			}
+		}
+		for _, entry := range typed.Sorted(c.Files) {
+			if !entry.Value.Interpolate || entry.Value.Secret.Name != "" {
+				continue
+			}
+			if content, err := base64.StdEncoding.DecodeString(entry.Value.Content); err == nil {
+				result = append(result, referenceKeys(string(content))...)
+			}
+		}
+	}
+	for _, env := range app.Spec.Environment {
+		result = append(result, referenceKeys(env.Value)...)
+	}
+	return
+}
+
+// interpolationWarnings returns the ${service.NAME} and ${endpoint.NAME} references to services that are not part of
+// the app and the references to endpoints that are not published yet. Both are left as they are, the containers are
+// deployed anyway and updated once the endpoints are published.
+func interpolationWarnings(app *v1.AppInstance) (result []string) {
+	var (
+		unknown = map[string]bool{}
+		pending = map[string]bool{}
+	)
+	for _, containers := range []map[string]v1.Container{app.Status.AppSpec.Containers, app.Status.AppSpec.Jobs} {
+		for _, container := range containers {
+			for _, key := range containerReferenceKeys(app, container) {
+				kind, field, _ := strings.Cut(key, ".")
+				if kind != "service" && kind != "endpoint" {
+					continue
+				}
+				if !isAppService(app, field) {
+					unknown["${"+key+"}"] = true
+				} else if _, ok := endpointAddress(app, field); kind == "endpoint" && !ok {
+					pending[field] = true
+				}
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		result = append(result, "unknown service references "+strings.Join(typed.SortedKeys(unknown), ", "))
+	}
+	if len(pending) > 0 {
+		result = append(result, "waiting for endpoints of "+strings.Join(typed.SortedKeys(pending), ", "))
+	}
+	return
+}
+
+func isAppService(app *v1.AppInstance, name string) bool {
+	if _, ok := app.Status.AppSpec.Containers[name]; ok {
+		return true
+	}
+	if _, ok := app.Status.AppSpec.Routers[name]; ok {
+		return true
+	}
+	if _, ok := app.Status.AppSpec.Services[name]; ok {
+		return true
+	}
+	if _, ok := app.Status.AppSpec.Acorns[name]; ok {
+		return true
+	}
+	for _, link := range app.Spec.Links {
+		if link.Target == name {
+			return true
+		}
+	}
+	return false
+}
+
+// inlineSecrets returns the secrets referenced inside of an env value
+func inlineSecrets(value string) (result []v1.SecretReference) {
+	for _, key := range referenceKeys(value) {
+		if secret, ok, err := v1.ParseSecretReference(key); err == nil && ok {
+			result = append(result, secret)
+		}
+	}
+	return
+}
+
+func fieldRefEnv(name, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fieldPath,
+			},
+		},
+	}
+}
+
+func appImage(app *v1.AppInstance) (string, bool) {
+	if tags.IsLocalReference(app.Status.AppImage.ID) {
+		return app.Status.AppImage.ID, true
+	} else if app.Status.AppImage.ID != "" && app.Status.AppImage.Digest != "" {
+		tag, err := name.NewTag(app.Status.AppImage.ID)
+		if err == nil {
+			return tag.Digest(app.Status.AppImage.Digest).String(), true
+		}
+	}
+	return "", false
+}
+
+// endpointAddress returns the address of the published endpoint of the service, preferring HTTP endpoints. It is not
+// known until the endpoint is created and no longer pending.
+func endpointAddress(app *v1.AppInstance, serviceName string) (string, bool) {
+	var result string
+	for _, endpoint := range app.Status.Endpoints {
+		if endpoint.Target != serviceName || endpoint.Pending {
+			continue
+		}
+		if endpoint.Protocol.IsHTTP() {
+			return endpoint.Address, true
+		}
+		if result == "" {
+			result = endpoint.Address
+		}
+	}
+	return result, result != ""
+}
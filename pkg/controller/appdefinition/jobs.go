@@ -1,6 +1,7 @@
 package appdefinition
 
 import (
+	"strconv"
 	"strings"
 
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
@@ -8,6 +9,7 @@ import (
 	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/google/go-containerregistry/pkg/name"
+	"golang.org/x/exp/slices"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,8 +26,9 @@ func addJobs(req router.Request, appInstance *v1.AppInstance, tag name.Reference
 }
 
 func toJobs(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSecrets, tag name.Reference) (result []kclient.Object, _ error) {
+	classes := newComputeClasses(req)
 	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Jobs) {
-		job, err := toJob(req, appInstance, pullSecrets, tag, entry.Key, entry.Value)
+		job, err := toJob(req, appInstance, pullSecrets, tag, entry.Key, entry.Value, classes)
 		if err != nil {
 			return nil, err
 		}
@@ -33,11 +36,67 @@ func toJobs(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSe
 		if perms := v1.FindPermission(job.GetName(), appInstance.Spec.Permissions); perms.HasRules() {
 			result = append(result, toPermissions(perms, job.GetLabels(), job.GetAnnotations(), appInstance)...)
 		}
-		result = append(result, job, sa)
+		// The service account is kept for jobs that are skipped so it exists when the job runs on delete
+		result = append(result, sa)
+		if runsOnEvent(entry.Value, jobEvent(appInstance)) {
+			result = append(result, job)
+		}
 	}
 	return result, nil
 }
 
+// hasEvents returns true if the job only runs on the app events it lists. Jobs without events, and scheduled jobs,
+// run on every change to the job.
+func hasEvents(job v1.Container) bool {
+	return len(job.Events) > 0 && job.Schedule == ""
+}
+
+// jobEvent returns the app event that is currently happening
+func jobEvent(appInstance *v1.AppInstance) string {
+	switch {
+	case !appInstance.DeletionTimestamp.IsZero():
+		return v1.JobEventDelete
+	case appInstance.Spec.Stop != nil && *appInstance.Spec.Stop:
+		return v1.JobEventStop
+	case appInstance.Generation <= 1:
+		return v1.JobEventCreate
+	}
+	return v1.JobEventUpdate
+}
+
+func runsOnEvent(job v1.Container, event string) bool {
+	if !hasEvents(job) {
+		return event != v1.JobEventDelete
+	}
+	return slices.Contains(job.Events, event)
+}
+
+// applyJobEvent records the event on the job. The event, and the generation for updates, are set on the pod
+// template so the job runs again for each event.
+func applyJobEvent(appInstance *v1.AppInstance, jobSpec *batchv1.JobSpec, job v1.Container) {
+	if !hasEvents(job) {
+		return
+	}
+
+	event := jobEvent(appInstance)
+	template := &jobSpec.Template
+	template.Labels = labels.Merge(template.Labels, map[string]string{
+		labels.AcornJobEvent: event,
+	})
+	if event == v1.JobEventUpdate {
+		template.Annotations = labels.Merge(template.Annotations, map[string]string{
+			labels.AcornAppGeneration: strconv.Itoa(int(appInstance.Generation)),
+		})
+	}
+
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env, corev1.EnvVar{
+			Name:  "ACORN_EVENT",
+			Value: event,
+		})
+	}
+}
+
 func setTerminationPath(containers []corev1.Container) (result []corev1.Container) {
 	for _, c := range containers {
 		c.TerminationMessagePath = "/run/secrets/output"
@@ -46,8 +105,16 @@ func setTerminationPath(containers []corev1.Container) (result []corev1.Containe
 	return
 }
 
-func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSecrets, tag name.Reference, name string, container v1.Container) (kclient.Object, error) {
-	containers, initContainers := toContainers(appInstance, tag, name, container)
+func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSecrets, tag name.Reference, name string, container v1.Container, classes *computeClasses) (kclient.Object, error) {
+	class, err := classes.get(appInstance, name, container)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, initContainers, err := toContainers(appInstance, tag, name, container, class)
+	if err != nil {
+		return nil, err
+	}
 
 	secretAnnotations, err := getSecretAnnotations(req, appInstance, container)
 	if err != nil {
@@ -62,6 +129,11 @@ func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSec
 		return nil, err
 	}
 
+	terminationGracePeriod, err := toTerminationGracePeriod(name, container)
+	if err != nil {
+		return nil, err
+	}
+
 	jobSpec := batchv1.JobSpec{
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
@@ -72,7 +144,7 @@ func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSec
 				Annotations: labels.Merge(podAnnotations(appInstance, name, container), baseAnnotations),
 			},
 			Spec: corev1.PodSpec{
-				TerminationGracePeriodSeconds: &[]int64{5}[0],
+				TerminationGracePeriodSeconds: terminationGracePeriod,
 				ImagePullSecrets:              pullSecrets.ForContainer(name, append(containers, initContainers...)),
 				EnableServiceLinks:            new(bool),
 				RestartPolicy:                 corev1.RestartPolicyNever,
@@ -84,6 +156,10 @@ func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSec
 		},
 	}
 
+	applyComputeClass(&jobSpec.Template.Spec, class)
+	applyHostNamespaces(&jobSpec.Template.Spec, appInstance, name, container)
+	applyJobEvent(appInstance, &jobSpec, container)
+
 	if container.Schedule == "" {
 		return &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
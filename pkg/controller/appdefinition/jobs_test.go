@@ -1,10 +1,17 @@
 package appdefinition
 
 import (
+	"context"
 	"testing"
 
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestJobs(t *testing.T) {
@@ -18,3 +25,48 @@ func TestJobsLabels(t *testing.T) {
 func TestCronJobs(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/cronjob", DeploySpec)
 }
+
+func TestJobsEvents(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/job/events", DeploySpec)
+}
+
+func TestJobsEventsStatus(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/job/events-status", JobStatus)
+}
+
+func TestDeleteJobsFinalizer(t *testing.T) {
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "app",
+			Namespace:  "default",
+			Finalizers: []string{"other"},
+		},
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Jobs: map[string]v1.Container{
+					"cleanup": {Events: []string{v1.JobEventDelete}},
+					"migrate": {},
+				},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(app).Build()
+	run := func() *v1.AppInstance {
+		t.Helper()
+		current := &v1.AppInstance{}
+		require.NoError(t, c.Get(context.Background(), router.Key("default", "app"), current))
+		require.NoError(t, RunDeleteJobs(router.Request{Ctx: context.Background(), Client: c, Object: current}, &tester.Response{}))
+		require.NoError(t, c.Get(context.Background(), router.Key("default", "app"), current))
+		return current
+	}
+
+	// Added after the finalizers that are already set
+	assert.Equal(t, []string{"other", DeleteJobsFinalizer}, run().Finalizers)
+
+	// Removed by name once the app no longer has delete jobs
+	current := run()
+	current.Status.AppSpec.Jobs = map[string]v1.Container{"migrate": {}}
+	require.NoError(t, c.Update(context.Background(), current))
+	assert.Equal(t, []string{"other"}, run().Finalizers)
+}
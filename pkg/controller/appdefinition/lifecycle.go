@@ -0,0 +1,62 @@
+package appdefinition
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultStopGracePeriodSeconds is how long containers are given to stop if no stopGracePeriod is set
+const defaultStopGracePeriodSeconds = 5
+
+func toLifecycle(container v1.Container) *corev1.Lifecycle {
+	if container.Lifecycle == nil {
+		return nil
+	}
+
+	result := &corev1.Lifecycle{
+		PostStart: toLifecycleHandler(container.Lifecycle.PostStart),
+		PreStop:   toLifecycleHandler(container.Lifecycle.PreStop),
+	}
+	if result.PostStart == nil && result.PreStop == nil {
+		return nil
+	}
+	return result
+}
+
+func toLifecycleHandler(handler *v1.LifecycleHandler) *corev1.LifecycleHandler {
+	if handler == nil {
+		return nil
+	}
+
+	// Hooks are written in the same forms as probes so they are translated the same way
+	ph := toProbeHandler(v1.Probe{
+		Exec: handler.Exec,
+		HTTP: handler.HTTP,
+	})
+	if ph.Exec == nil && ph.HTTPGet == nil {
+		return nil
+	}
+	return &corev1.LifecycleHandler{
+		Exec:    ph.Exec,
+		HTTPGet: ph.HTTPGet,
+	}
+}
+
+// toTerminationGracePeriod returns the number of seconds the pod is given to stop, rounded up to a whole second
+func toTerminationGracePeriod(name string, container v1.Container) (*int64, error) {
+	if container.StopGracePeriod == "" {
+		return &[]int64{defaultStopGracePeriodSeconds}[0], nil
+	}
+
+	period, err := time.ParseDuration(container.StopGracePeriod)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stop grace period [%s] for container [%s]: %w", container.StopGracePeriod, name, err)
+	}
+
+	seconds := int64(math.Ceil(period.Seconds()))
+	return &seconds, nil
+}
@@ -35,7 +35,13 @@ func addExpose(req router.Request, app *v1.AppInstance, resp router.Response) er
 	}
 	resp.Objects(links...)
 
-	objs, err := expose.Containers(app)
+	objs, err := expose.ExternalServices(app)
+	if err != nil {
+		return err
+	}
+	resp.Objects(objs...)
+
+	objs, err = expose.Containers(app)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,135 @@
+package appdefinition
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func effectiveMemory(app *v1.AppInstance, containerName string, container v1.Container, class *v1.ComputeClassInstance) (v1.ResourceRequirement, error) {
+	req := app.Spec.Memory.Effective(containerName, container.Memory)
+	if class != nil {
+		req = class.Memory.WithDefault(req)
+	}
+	return withMemoryVolumes(req, app.Status.AppSpec.MemoryVolumeSize(&app.Spec, container))
+}
+
+// withMemoryVolumes adds the size of memory backed volumes to the memory of a container, because the files of a tmpfs
+// are charged to the memory of the container that writes them
+func withMemoryVolumes(req v1.ResourceRequirement, size resource.Quantity) (v1.ResourceRequirement, error) {
+	if size.IsZero() {
+		return req, nil
+	}
+	return req.Add(size)
+}
+
+func effectiveCPU(app *v1.AppInstance, containerName string, container v1.Container, class *v1.ComputeClassInstance) v1.ResourceRequirement {
+	req := app.Spec.CPU.Effective(containerName, container.CPU)
+	if class != nil {
+		req = class.CPU.WithDefault(req)
+	}
+	return req
+}
+
+func toResources(app *v1.AppInstance, containerName string, container v1.Container, class *v1.ComputeClassInstance) (result corev1.ResourceRequirements, _ error) {
+	memory, err := effectiveMemory(app, containerName, container, class)
+	if err != nil {
+		return result, fmt.Errorf("invalid memory: %w", err)
+	}
+	if err := addResource(&result, corev1.ResourceMemory, memory); err != nil {
+		return result, err
+	}
+	if err := addResource(&result, corev1.ResourceCPU, effectiveCPU(app, containerName, container, class)); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func addResource(reqs *corev1.ResourceRequirements, name corev1.ResourceName, req v1.ResourceRequirement) error {
+	if req.Request != "" {
+		q, err := resource.ParseQuantity(req.Request)
+		if err != nil {
+			return fmt.Errorf("invalid %s request [%s]: %w", name, req.Request, err)
+		}
+		if reqs.Requests == nil {
+			reqs.Requests = corev1.ResourceList{}
+		}
+		reqs.Requests[name] = q
+	}
+	if req.Limit != "" {
+		q, err := resource.ParseQuantity(req.Limit)
+		if err != nil {
+			return fmt.Errorf("invalid %s limit [%s]: %w", name, req.Limit, err)
+		}
+		if reqs.Limits == nil {
+			reqs.Limits = corev1.ResourceList{}
+		}
+		reqs.Limits[name] = q
+	}
+	return nil
+}
+
+func formatResource(name string, req v1.ResourceRequirement) string {
+	switch {
+	case req.Request == "" && req.Limit == "":
+		return ""
+	case req.Request == req.Limit:
+		return name + ":" + req.Request
+	case req.Request == "":
+		return fmt.Sprintf("%s:-/%s", name, req.Limit)
+	case req.Limit == "":
+		return fmt.Sprintf("%s:%s/-", name, req.Request)
+	}
+	return fmt.Sprintf("%s:%s/%s", name, req.Request, req.Limit)
+}
+
+// resources renders the effective memory and cpu of each container, sidecar and job in the app
+// in the format name=memory:request/limit,cpu:request/limit
+func resources(req router.Request, app *v1.AppInstance) (string, error) {
+	var (
+		result  []string
+		classes = newComputeClasses(req)
+	)
+
+	add := func(containerName string, container v1.Container, class *v1.ComputeClassInstance) error {
+		memory, err := effectiveMemory(app, containerName, container, class)
+		if err != nil {
+			return fmt.Errorf("invalid memory of container [%s]: %w", containerName, err)
+		}
+		var parts []string
+		if s := formatResource("memory", memory); s != "" {
+			parts = append(parts, s)
+		}
+		if s := formatResource("cpu", effectiveCPU(app, containerName, container, class)); s != "" {
+			parts = append(parts, s)
+		}
+		if len(parts) > 0 {
+			result = append(result, containerName+"="+strings.Join(parts, ","))
+		}
+		return nil
+	}
+
+	for _, containers := range []map[string]v1.Container{app.Status.AppSpec.Containers, app.Status.AppSpec.Jobs} {
+		for _, entry := range typed.Sorted(containers) {
+			class, err := classes.get(app, entry.Key, entry.Value)
+			if err != nil {
+				return "", err
+			}
+			if err := add(entry.Key, entry.Value, class); err != nil {
+				return "", err
+			}
+			for _, sidecar := range typed.Sorted(entry.Value.Sidecars) {
+				if err := add(sidecar.Key, sidecar.Value, class); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	return strings.Join(result, " "), nil
+}
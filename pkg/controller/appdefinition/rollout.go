@@ -0,0 +1,57 @@
+package appdefinition
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyRollout sets the update strategy and progress deadline of the container on the deployment. A deployment that
+// is already set to recreate is stateful and is never changed to a rolling update.
+func applyRollout(dep *appsv1.Deployment, container v1.Container) error {
+	rollout := container.Rollout
+	if rollout == nil {
+		return nil
+	}
+
+	if rollout.ProgressDeadline != "" {
+		deadline, err := time.ParseDuration(rollout.ProgressDeadline)
+		if err != nil {
+			return fmt.Errorf("invalid progress deadline [%s] for container [%s]: %w", rollout.ProgressDeadline, dep.Name, err)
+		}
+		seconds := int32(deadline.Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		dep.Spec.ProgressDeadlineSeconds = &seconds
+	}
+
+	if dep.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return nil
+	}
+
+	if rollout.Strategy == v1.RolloutStrategyRecreate {
+		dep.Spec.Strategy.Type = appsv1.RecreateDeploymentStrategyType
+	} else if rollout.MaxSurge != nil || rollout.MaxUnavailable != nil {
+		dep.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+		dep.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxSurge:       rollout.MaxSurge,
+			MaxUnavailable: rollout.MaxUnavailable,
+		}
+	}
+
+	return nil
+}
+
+// isRolloutStalled returns true if the deployment has reported that it exceeded its progress deadline
+func isRolloutStalled(dep *appsv1.Deployment) bool {
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing {
+			return cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
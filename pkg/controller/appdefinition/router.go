@@ -3,6 +3,8 @@ package appdefinition
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -155,50 +157,246 @@ func toRouter(appInstance *v1.AppInstance, routerName string, router v1.Router)
 }
 
 func toNginxConf(routerName string, router v1.Router) (string, string) {
-	buf := &strings.Builder{}
-	buf.WriteString("server {\nlisten 8080;\n")
-	for _, route := range router.Routes {
-		if route.TargetServiceName == "" || route.Path == "" {
+	var (
+		upstreams = &strings.Builder{}
+		buf       = &strings.Builder{}
+		locations []*nginxLocation
+		byName    = map[string]*nginxLocation{}
+	)
+	for i, route := range router.Routes {
+		if route.Path == "" {
 			continue
 		}
-		port := 80
-		if route.TargetPort != 0 {
-			port = route.TargetPort
-		}
-		buf.WriteString("location ")
-		buf.WriteString("= ")
-		buf.WriteString(route.Path)
-		buf.WriteString(" {\n  proxy_pass ")
-		buf.WriteString("http://")
-		buf.WriteString(route.TargetServiceName)
-		buf.WriteString(":")
-		buf.WriteString(strconv.Itoa(port))
-		buf.WriteString(";\n}\n")
-		if route.PathType == v1.PathTypePrefix && !strings.HasSuffix(route.Path, "/") {
-			buf.WriteString("location ")
-			buf.WriteString(route.Path)
-			buf.WriteString("/")
-			buf.WriteString(" {\n  proxy_pass ")
-			buf.WriteString("http://")
-			buf.WriteString(route.TargetServiceName)
-			buf.WriteString(":")
-			buf.WriteString(strconv.Itoa(port))
-			buf.WriteString(";\n}\n")
+		action, ok := toNginxAction(upstreams, i, route)
+		if !ok {
+			continue
 		}
-		if route.PathType == v1.PathTypePrefix && route.Path == "/" {
-			buf.WriteString("location ")
-			buf.WriteString("/")
-			buf.WriteString(" {\n  proxy_pass ")
-			buf.WriteString("http://")
-			buf.WriteString(route.TargetServiceName)
-			buf.WriteString(":")
-			buf.WriteString(strconv.Itoa(port))
-			buf.WriteString(";\n}\n")
+		for _, name := range nginxLocationNames(route) {
+			location, ok := byName[name]
+			if !ok {
+				location = &nginxLocation{
+					name: name,
+				}
+				byName[name] = location
+				locations = append(locations, location)
+			}
+			location.routes = append(location.routes, nginxRoute{
+				index:  i,
+				match:  route.Match,
+				action: action,
+			})
 		}
 	}
+
+	buf.WriteString("server {\nlisten 8080;\n")
+	for _, location := range locations {
+		writeNginxLocation(buf, location)
+	}
 	buf.WriteString("}\n")
 
-	conf := buf.String()
+	conf := upstreams.String() + buf.String()
 	hash := sha256.Sum256([]byte(conf))
 	return conf, name2.SafeConcatName(routerName, hex.EncodeToString(hash[:])[:8])
 }
+
+// nginxLocation is a location block of the router, routes with the same location are tried in order
+type nginxLocation struct {
+	name   string
+	routes []nginxRoute
+}
+
+type nginxRoute struct {
+	index  int
+	match  *v1.RouteMatch
+	action nginxAction
+}
+
+// nginxAction is what the router does with a request for a route, either a redirect or a proxy to the targets with
+// an optional rewrite of the path
+type nginxAction struct {
+	rewrite  string
+	proxy    []string
+	redirect string
+}
+
+func nginxLocationNames(route v1.Route) []string {
+	names := []string{"= " + route.Path}
+	if route.PathType == v1.PathTypePrefix && !strings.HasSuffix(route.Path, "/") {
+		names = append(names, route.Path+"/")
+	}
+	if route.PathType == v1.PathTypePrefix && route.Path == "/" {
+		names = append(names, "/")
+	}
+	return names
+}
+
+func targetAddress(target v1.WeightedTarget) string {
+	port := 80
+	if target.TargetPort != 0 {
+		port = target.TargetPort
+	}
+	return target.TargetServiceName + ":" + strconv.Itoa(port)
+}
+
+func toNginxAction(buf *strings.Builder, index int, route v1.Route) (nginxAction, bool) {
+	if route.Redirect != nil {
+		code := route.Redirect.Code
+		if code == 0 {
+			code = http.StatusFound
+		}
+		return nginxAction{
+			redirect: "return " + strconv.Itoa(code) + " " + nginxQuote(route.Redirect.URL) + ";",
+		}, true
+	}
+
+	proxy, ok := toNginxUpstream(buf, index, route)
+	if !ok {
+		return nginxAction{}, false
+	}
+	return nginxAction{
+		rewrite: nginxRewrite(route),
+		proxy:   proxy,
+	}, true
+}
+
+// nginxRewrite returns the rewrite directive that replaces the path of the route with the rewrite path, or removes it
+// for stripPrefix. The rest of the path and the query are kept.
+func nginxRewrite(route v1.Route) string {
+	replacement := route.Rewrite
+	if replacement == "" && !route.StripPrefix {
+		return ""
+	}
+
+	if route.PathType == v1.PathTypeExact {
+		if replacement == "" {
+			replacement = "/"
+		}
+		return "rewrite ^" + regexp.QuoteMeta(route.Path) + "$ " + replacement + " break;"
+	}
+
+	prefix := strings.TrimSuffix(route.Path, "/")
+	replacement = strings.TrimSuffix(replacement, "/")
+	return "rewrite ^" + regexp.QuoteMeta(prefix) + "/?(.*)$ " + replacement + "/$1 break;"
+}
+
+// toNginxUpstream returns the directives of a location that proxies to the targets of the route. A route with a single
+// target is proxied directly, otherwise an upstream block with the weights of the targets is written to buf. With a
+// sticky cookie the upstream hashes the value of the cookie, a client without the cookie gets the id of its first
+// request.
+func toNginxUpstream(buf *strings.Builder, index int, route v1.Route) ([]string, bool) {
+	var targets []v1.WeightedTarget
+	for _, target := range route.WeightedTargets() {
+		if target.TargetServiceName != "" && target.GetWeight() > 0 {
+			targets = append(targets, target)
+		}
+	}
+
+	switch {
+	case len(targets) == 0:
+		return nil, false
+	case len(targets) == 1 && route.StickyCookie == "":
+		return []string{"proxy_pass http://" + targetAddress(targets[0]) + ";"}, true
+	}
+
+	var (
+		upstream = "route-" + strconv.Itoa(index)
+		key      = "$acorn_route_" + strconv.Itoa(index)
+		proxy    = []string{"proxy_pass http://" + upstream + ";"}
+	)
+
+	if route.StickyCookie != "" {
+		buf.WriteString("map $cookie_" + route.StickyCookie + " " + key + " {\n")
+		buf.WriteString("  \"\" $request_id;\n")
+		buf.WriteString("  default $cookie_" + route.StickyCookie + ";\n")
+		buf.WriteString("}\n")
+		proxy = append(proxy, "add_header Set-Cookie \""+route.StickyCookie+"="+key+"; Path=/; HttpOnly\" always;")
+	}
+
+	buf.WriteString("upstream " + upstream + " {\n")
+	if route.StickyCookie != "" {
+		buf.WriteString("  hash " + key + " consistent;\n")
+	}
+	for _, target := range targets {
+		buf.WriteString("  server " + targetAddress(target) + " weight=" + strconv.Itoa(target.GetWeight()) + ";\n")
+	}
+	buf.WriteString("}\n")
+
+	return proxy, true
+}
+
+// writeNginxLocation writes the location block for the routes of location. Routes with a match are tried first, in
+// order, and the first route without a match is the default of the location. Matches are selected with if blocks,
+// because nginx can not fall through to another location. They are evaluated in reverse order into $acorn_route so
+// that the first matching route wins and only the if block of that route is true.
+func writeNginxLocation(buf *strings.Builder, location *nginxLocation) {
+	var (
+		matched   []nginxRoute
+		defaultTo *nginxAction
+	)
+	for i, route := range location.routes {
+		if route.match != nil {
+			matched = append(matched, route)
+		} else if defaultTo == nil {
+			defaultTo = &location.routes[i].action
+		}
+	}
+
+	buf.WriteString("location " + location.name + " {\n")
+	if len(matched) > 0 {
+		buf.WriteString("  set $acorn_route \"\";\n")
+		for i := len(matched) - 1; i >= 0; i-- {
+			writeNginxMatch(buf, matched[i])
+		}
+		for _, route := range matched {
+			buf.WriteString("  if ($acorn_route = \"" + strconv.Itoa(route.index) + "\") {\n")
+			writeNginxAction(buf, "    ", route.action, true)
+			buf.WriteString("  }\n")
+		}
+	}
+	if defaultTo == nil {
+		buf.WriteString("  return 404;\n")
+	} else {
+		writeNginxAction(buf, "  ", *defaultTo, false)
+	}
+	buf.WriteString("}\n")
+}
+
+func writeNginxMatch(buf *strings.Builder, route nginxRoute) {
+	buf.WriteString("  set $acorn_match \"\";\n")
+	if len(route.match.Methods) > 0 {
+		buf.WriteString("  if ($request_method !~ \"^(" + strings.Join(route.match.Methods, "|") + ")$\") {\n")
+		buf.WriteString("    set $acorn_match \"no\";\n")
+		buf.WriteString("  }\n")
+	}
+	for _, name := range typed.SortedKeys(route.match.Headers) {
+		variable := "$http_" + strings.ReplaceAll(strings.ToLower(name), "-", "_")
+		value := "^" + regexp.QuoteMeta(route.match.Headers[name]) + "$"
+		buf.WriteString("  if (" + variable + " !~ " + nginxQuote(value) + ") {\n")
+		buf.WriteString("    set $acorn_match \"no\";\n")
+		buf.WriteString("  }\n")
+	}
+	buf.WriteString("  if ($acorn_match = \"\") {\n")
+	buf.WriteString("    set $acorn_route \"" + strconv.Itoa(route.index) + "\";\n")
+	buf.WriteString("  }\n")
+}
+
+// writeNginxAction writes the directives of action. In an if block the remaining rewrite directives of the location
+// are skipped with break, which the rewrite and return directives already do.
+func writeNginxAction(buf *strings.Builder, indent string, action nginxAction, inIf bool) {
+	if action.redirect != "" {
+		buf.WriteString(indent + action.redirect + "\n")
+		return
+	}
+	if action.rewrite != "" {
+		buf.WriteString(indent + action.rewrite + "\n")
+	} else if inIf {
+		buf.WriteString(indent + "break;\n")
+	}
+	for _, line := range action.proxy {
+		buf.WriteString(indent + line + "\n")
+	}
+}
+
+func nginxQuote(s string) string {
+	return "\"" + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + "\""
+}
@@ -10,3 +10,11 @@ import (
 func TestRouter(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/router", DeploySpec)
 }
+
+func TestRouterWeighted(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/router-weighted", DeploySpec)
+}
+
+func TestRouterMatch(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/router-match", DeploySpec)
+}
@@ -10,12 +10,14 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/condition"
 	"github.com/acorn-io/acorn/pkg/encryption/nacl"
 	"github.com/acorn-io/acorn/pkg/images"
 	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/secretprovider"
 	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/rancher/wrangler/pkg/data/convert"
@@ -321,6 +323,10 @@ func generateBasic(req router.Request, appInstance *v1.AppInstance, secretName s
 func updateOrCreate(req router.Request, existing, secret *corev1.Secret) (*corev1.Secret, error) {
 	var err error
 
+	if existing != nil {
+		carryRotation(existing, secret)
+	}
+
 	secret.Data, err = nacl.DecryptNamespacedDataMap(req.Ctx, req.Client, secret.Data, secret.Namespace)
 	if err != nil {
 		return nil, fmt.Errorf("decrypting %s/%s: %w", secret.Namespace, secret.Name, err)
@@ -400,6 +406,16 @@ func generateSecret(secrets map[string]*corev1.Secret, req router.Request, appIn
 		}, secretName)
 	}
 
+	if rotated, err := rotateSecret(secretRef, existing); err != nil {
+		return nil, err
+	} else if rotated != existing {
+		// Saved before generating so the previous values and history are not lost if generating fails
+		if err := req.Client.Update(req.Ctx, rotated); err != nil {
+			return nil, err
+		}
+		existing = rotated
+	}
+
 	switch secretRef.Type {
 	case "opaque":
 		return generateOpaque(req, appInstance, secretName, secretRef, existing)
@@ -411,12 +427,20 @@ func generateSecret(secrets map[string]*corev1.Secret, req router.Request, appIn
 		return generateToken(req, appInstance, secretName, secretRef, existing)
 	case "template":
 		return generateTemplate(secrets, req, appInstance, secretName, secretRef, existing)
+	case "tls":
+		return generateTLS(secrets, req, appInstance, secretName, secretRef, existing)
+	case "rsa", "ed25519", "ssh":
+		return generateKeyPair(req, appInstance, secretName, secretRef, existing)
 	default:
 		return nil, err
 	}
 }
 
 func lookupSecret(ctx context.Context, req router.Request, parent *v1.AppInstance, namespace, secretName string) (*corev1.Secret, error) {
+	if ref, ok := secretprovider.ParseReference(secretName); ok {
+		return lookupProviderSecret(ctx, req, namespace, ref)
+	}
+
 	parts := strings.Split(secretName, ".")
 	for i := range parts {
 		if i+1 >= len(parts) {
@@ -519,6 +543,7 @@ func CreateSecrets(req router.Request, resp router.Response) (err error) {
 		missing     []string
 		errored     []string
 		waiting     []string
+		updateAt    time.Time
 		appInstance = req.Object.(*v1.AppInstance)
 		secrets     = map[string]*corev1.Secret{}
 		cond        = condition.Setter(appInstance, resp, v1.AppInstanceConditionSecrets)
@@ -588,6 +613,12 @@ func CreateSecrets(req router.Request, resp router.Response) (err error) {
 			continue
 		}
 
+		for _, next := range []func(v1.Secret, *corev1.Secret) (time.Time, bool){certRenewAt, nextRotation, nextProviderRefresh} {
+			if t, ok := next(entry.secret, secret); ok && (updateAt.IsZero() || t.Before(updateAt)) {
+				updateAt = t
+			}
+		}
+
 		labelMap := map[string]string{
 			labels.AcornAppName:      appInstance.Name,
 			labels.AcornAppNamespace: appInstance.Namespace,
@@ -598,6 +629,11 @@ func CreateSecrets(req router.Request, resp router.Response) (err error) {
 
 		annotations := labels.GatherScoped(secretName, v1.LabelTypeSecret, appInstance.Status.AppSpec.Annotations,
 			entry.secret.Annotations, appInstance.Spec.Annotations)
+		if history, ok := secret.Annotations[labels.AcornSecretRotations]; ok {
+			annotations = labels.Merge(annotations, map[string]string{
+				labels.AcornSecretRotations: history,
+			})
+		}
 
 		resp.Objects(&corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -611,6 +647,11 @@ func CreateSecrets(req router.Request, resp router.Response) (err error) {
 		})
 	}
 
+	if !updateAt.IsZero() {
+		// Come back when the first certificate must be regenerated, secret rotated or external secret refreshed
+		resp.RetryAfter(updateAt.Sub(now()))
+	}
+
 	return nil
 }
 
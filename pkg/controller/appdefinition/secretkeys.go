@@ -0,0 +1,431 @@
+package appdefinition
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/ports"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	secretPrivateKeyKey = "key"
+	secretPublicKeyKey  = "pub"
+	secretCACertKey     = "ca.crt"
+
+	defaultCertDuration    = 90 * 24 * time.Hour
+	defaultCertRenewBefore = 30 * 24 * time.Hour
+	defaultRSABits         = 2048
+)
+
+// now is replaced in tests
+var now = time.Now
+
+func generateTLS(secrets map[string]*corev1.Secret, req router.Request, appInstance *v1.AppInstance, secretName string, secretRef v1.Secret, existing *corev1.Secret) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: secretName + "-",
+			Namespace:    appInstance.Namespace,
+			Labels:       labelsForSecret(secretName, appInstance, secretRef),
+			Annotations:  annotationsForSecret(secretName, appInstance, secretRef),
+		},
+		Data: seedData(existing, nil, corev1.TLSCertKey, corev1.TLSPrivateKeyKey, secretCACertKey),
+		Type: v1.SecretTypeTLS,
+	}
+
+	duration, renewBefore, err := certDurations(secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: convert.ToString(secretRef.Params["commonName"]),
+		},
+		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	if template.Subject.CommonName == "" {
+		template.Subject.CommonName = secretName
+	}
+
+	isCA := convert.ToBool(secretRef.Params["ca"])
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+		if err := addSANs(req, appInstance, secretRef, template); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		caCert *x509.Certificate
+		caKey  crypto.Signer
+		caPEM  []byte
+	)
+	if caSecretName := convert.ToString(secretRef.Params["caSecret"]); caSecretName != "" && !isCA {
+		caSecret, err := getOrCreateSecret(secrets, req, appInstance, caSecretName)
+		if err != nil {
+			return nil, err
+		}
+		caPEM = caSecret.Data[corev1.TLSCertKey]
+		caCert, caKey, err = parseCertAndKey(caPEM, caSecret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA secret [%s]: %w", caSecretName, err)
+		}
+		if !caCert.IsCA {
+			return nil, fmt.Errorf("secret [%s] is not a CA, set ca: true in its params", caSecretName)
+		}
+	}
+
+	if certNeedsRenewal(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], template, caCert, renewBefore) {
+		key, err := generatePrivateKey(secretRef)
+		if err != nil {
+			return nil, err
+		}
+
+		serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			return nil, err
+		}
+		template.SerialNumber = serial
+		template.NotBefore = now().Add(-time.Minute).UTC()
+		template.NotAfter = now().Add(duration).UTC()
+
+		// Self-signed unless a CA is given
+		parent, signer := template, key
+		if caCert != nil {
+			parent, signer = caCert, caKey
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+		if err != nil {
+			return nil, err
+		}
+		keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		secret.Data[corev1.TLSCertKey] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		secret.Data[corev1.TLSPrivateKeyKey] = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+	}
+
+	if caPEM != nil {
+		secret.Data[secretCACertKey] = caPEM
+	} else {
+		secret.Data[secretCACertKey] = secret.Data[corev1.TLSCertKey]
+	}
+
+	cert, _, err := parseCertAndKey(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+	secret.Annotations = labels.Merge(secret.Annotations, map[string]string{
+		labels.AcornCertNotValidBefore: cert.NotBefore.Format(time.RFC3339),
+		labels.AcornCertNotValidAfter:  cert.NotAfter.Format(time.RFC3339),
+	})
+
+	return updateOrCreate(req, existing, secret)
+}
+
+func certDurations(secretRef v1.Secret) (duration time.Duration, renewBefore time.Duration, err error) {
+	duration, renewBefore = defaultCertDuration, defaultCertRenewBefore
+	if d := convert.ToString(secretRef.Params["duration"]); d != "" {
+		duration, err = time.ParseDuration(d)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid duration [%s]: %w", d, err)
+		}
+	}
+	if d := convert.ToString(secretRef.Params["renewBefore"]); d != "" {
+		renewBefore, err = time.ParseDuration(d)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid renewBefore [%s]: %w", d, err)
+		}
+	}
+	if renewBefore >= duration {
+		return 0, 0, fmt.Errorf("renewBefore [%s] must be less than duration [%s]", renewBefore, duration)
+	}
+	return duration, renewBefore, nil
+}
+
+// certRenewAt returns when the certificate of a generated tls secret must be regenerated
+func certRenewAt(secretRef v1.Secret, secret *corev1.Secret) (time.Time, bool) {
+	if secretRef.Type != "tls" {
+		return time.Time{}, false
+	}
+	_, renewBefore, err := certDurations(secretRef)
+	if err != nil {
+		return time.Time{}, false
+	}
+	cert, _, err := parseCertAndKey(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return cert.NotAfter.Add(-renewBefore), true
+}
+
+// addSANs adds the names of the services of the app, or the services listed in the params, and any extra SANs to
+// the certificate
+func addSANs(req router.Request, appInstance *v1.AppInstance, secretRef v1.Secret, template *x509.Certificate) error {
+	cfg, err := config.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	serviceNames := convert.ToStringSlice(secretRef.Params["services"])
+	if len(serviceNames) == 0 {
+		portSet, err := ports.New(appInstance)
+		if err != nil {
+			return err
+		}
+		serviceNames = portSet.ServiceNames()
+	}
+
+	var sans []string
+	for _, serviceName := range serviceNames {
+		sans = append(sans,
+			serviceName,
+			serviceName+"."+appInstance.Status.Namespace,
+			serviceName+"."+appInstance.Status.Namespace+".svc",
+			serviceName+"."+appInstance.Status.Namespace+"."+cfg.InternalClusterDomain)
+	}
+	sans = append(sans, convert.ToStringSlice(secretRef.Params["sans"])...)
+
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if !slices.Contains(template.DNSNames, san) {
+			template.DNSNames = append(template.DNSNames, san)
+		}
+	}
+	sort.Strings(template.DNSNames)
+	return nil
+}
+
+// certNeedsRenewal returns true if the existing certificate is missing, invalid, about to expire, no longer matches
+// the requested names or was not signed by the given CA
+func certNeedsRenewal(certPEM, keyPEM []byte, template *x509.Certificate, ca *x509.Certificate, renewBefore time.Duration) bool {
+	cert, _, err := parseCertAndKey(certPEM, keyPEM)
+	if err != nil {
+		return true
+	}
+	if !now().Before(cert.NotAfter.Add(-renewBefore)) {
+		return true
+	}
+	if cert.IsCA != template.IsCA || cert.Subject.CommonName != template.Subject.CommonName {
+		return true
+	}
+
+	dnsNames := slices.Clone(cert.DNSNames)
+	sort.Strings(dnsNames)
+	if !slices.Equal(dnsNames, template.DNSNames) {
+		return true
+	}
+	if !slices.EqualFunc(cert.IPAddresses, template.IPAddresses, net.IP.Equal) {
+		return true
+	}
+
+	if ca != nil {
+		return cert.CheckSignatureFrom(ca) != nil
+	}
+	// CheckSignatureFrom only accepts a CA as the parent so a self-signed leaf is checked directly
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) != nil
+}
+
+func parseCertAndKey(certPEM, keyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, nil, fmt.Errorf("no certificate found")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, fmt.Errorf("no private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return cert, signer, nil
+}
+
+func generatePrivateKey(secretRef v1.Secret) (crypto.Signer, error) {
+	switch algorithm := convert.ToString(secretRef.Params["algorithm"]); algorithm {
+	case "", "ecdsa":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "rsa":
+		bits, err := rsaBits(secretRef)
+		if err != nil {
+			return nil, err
+		}
+		return rsa.GenerateKey(rand.Reader, bits)
+	case "ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm [%s]", algorithm)
+	}
+}
+
+func rsaBits(secretRef v1.Secret) (int, error) {
+	if secretRef.Params["bits"] == nil {
+		return defaultRSABits, nil
+	}
+	bits, err := convert.ToNumber(secretRef.Params["bits"])
+	if err != nil {
+		return 0, err
+	}
+	return int(bits), nil
+}
+
+func generateKeyPair(req router.Request, appInstance *v1.AppInstance, secretName string, secretRef v1.Secret, existing *corev1.Secret) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: secretName + "-",
+			Namespace:    appInstance.Namespace,
+			Labels:       labelsForSecret(secretName, appInstance, secretRef),
+			Annotations:  annotationsForSecret(secretName, appInstance, secretRef),
+		},
+		Data: seedData(existing, nil, secretPrivateKeyKey, secretPublicKeyKey),
+		Type: corev1.SecretType(v1.SecretTypePrefix + secretRef.Type),
+	}
+
+	if len(secret.Data[secretPrivateKeyKey]) > 0 && len(secret.Data[secretPublicKeyKey]) > 0 {
+		return updateOrCreate(req, existing, secret)
+	}
+
+	var (
+		key crypto.Signer
+		err error
+	)
+	switch secretRef.Type {
+	case "rsa":
+		var bits int
+		bits, err = rsaBits(secretRef)
+		if err != nil {
+			return nil, err
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case "ed25519":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "ssh":
+		if convert.ToString(secretRef.Params["algorithm"]) == "rsa" {
+			var bits int
+			bits, err = rsaBits(secretRef)
+			if err != nil {
+				return nil, err
+			}
+			key, err = rsa.GenerateKey(rand.Reader, bits)
+		} else {
+			_, key, err = ed25519.GenerateKey(rand.Reader)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if secretRef.Type == "ssh" {
+		secret.Data[secretPrivateKeyKey], secret.Data[secretPublicKeyKey], err = marshalSSHKeyPair(key, secretName)
+	} else {
+		secret.Data[secretPrivateKeyKey], secret.Data[secretPublicKeyKey], err = marshalKeyPair(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return updateOrCreate(req, existing, secret)
+}
+
+// marshalKeyPair encodes the private key as PKCS #8 and the public key as PKIX, both PEM encoded
+func marshalKeyPair(key crypto.Signer) ([]byte, []byte, error) {
+	privateBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateBytes}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes}), nil
+}
+
+// marshalSSHKeyPair encodes the private key in the OpenSSH format and the public key in the authorized_keys format
+func marshalSSHKeyPair(key crypto.Signer, comment string) ([]byte, []byte, error) {
+	publicKey, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keyFields []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		keyFields = ssh.Marshal(struct {
+			N, E, D, Iqmp, P, Q *big.Int
+		}{k.N, big.NewInt(int64(k.E)), k.D, k.Precomputed.Qinv, k.Primes[0], k.Primes[1]})
+	case ed25519.PrivateKey:
+		keyFields = ssh.Marshal(struct {
+			Pub, Priv []byte
+		}{k.Public().(ed25519.PublicKey), k})
+	default:
+		return nil, nil, fmt.Errorf("unsupported ssh key type %T", key)
+	}
+
+	check := make([]byte, 4)
+	if _, err := rand.Read(check); err != nil {
+		return nil, nil, err
+	}
+	checkInt := binary.BigEndian.Uint32(check)
+
+	block := ssh.Marshal(struct {
+		Check1, Check2 uint32
+		KeyType        string
+	}{checkInt, checkInt, publicKey.Type()})
+	block = append(block, keyFields...)
+	block = append(block, ssh.Marshal(struct{ Comment string }{comment})...)
+	// The unencrypted block is padded to the cipher block size of 8 with the bytes 1, 2, 3...
+	for i := byte(1); len(block)%8 != 0; i++ {
+		block = append(block, i)
+	}
+
+	data := append([]byte("openssh-key-v1\x00"), ssh.Marshal(struct {
+		CipherName, KdfName, KdfOpts string
+		NumKeys                      uint32
+		PubKey, PrivKeyBlock         []byte
+	}{"none", "none", "", 1, publicKey.Marshal(), block})...)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: data}),
+		ssh.MarshalAuthorizedKey(publicKey), nil
+}
@@ -0,0 +1,254 @@
+package appdefinition
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func withNow(t *testing.T, ts time.Time) {
+	now = func() time.Time {
+		return ts
+	}
+	t.Cleanup(func() {
+		now = time.Now
+	})
+}
+
+func tlsTestApp(secrets map[string]v1.Secret) *v1.AppInstance {
+	return &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target-ns",
+			AppImage: v1.AppImage{
+				ID: "test",
+			},
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"web": {
+						Ports: []v1.PortDef{
+							{
+								Port:       80,
+								TargetPort: 80,
+								Protocol:   v1.ProtocolHTTP,
+							},
+						},
+					},
+				},
+				Secrets: secrets,
+			},
+		},
+	}
+}
+
+func parseTestCert(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatal("no certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestTLS_Gen(t *testing.T) {
+	withNow(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	h := tester.Harness{
+		Scheme:        scheme.Scheme,
+		ExpectedDelay: defaultCertDuration - defaultCertRenewBefore,
+	}
+	resp, err := h.InvokeFunc(t, tlsTestApp(map[string]v1.Secret{
+		"ca": {
+			Type: "tls",
+			Params: v1.GenericMap{
+				"ca": true,
+			},
+		},
+		"cert": {
+			Type: "tls",
+			Params: v1.GenericMap{
+				"caSecret": "ca",
+				"sans":     []any{"example.com", "10.0.0.1"},
+			},
+		},
+	}), CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, resp.Client.Created, 2)
+	assert.Len(t, resp.Collected, 3)
+
+	caSecret := resp.Client.Created[0].(*corev1.Secret)
+	assert.Equal(t, "ca", caSecret.Labels[labels.AcornSecretName])
+	assert.Equal(t, v1.SecretTypeTLS, caSecret.Type)
+	ca := parseTestCert(t, caSecret.Data[corev1.TLSCertKey])
+	assert.True(t, ca.IsCA)
+	assert.Equal(t, "ca", ca.Subject.CommonName)
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], caSecret.Data["ca.crt"])
+
+	certSecret := resp.Client.Created[1].(*corev1.Secret)
+	assert.Equal(t, "cert", certSecret.Labels[labels.AcornSecretName])
+	assert.True(t, strings.HasPrefix(certSecret.Name, "cert-"))
+	assert.Equal(t, caSecret.Data[corev1.TLSCertKey], certSecret.Data["ca.crt"])
+	assert.Equal(t, "2023-04-01T00:00:00Z", certSecret.Annotations[labels.AcornCertNotValidAfter])
+
+	cert := parseTestCert(t, certSecret.Data[corev1.TLSCertKey])
+	assert.False(t, cert.IsCA)
+	assert.NoError(t, cert.CheckSignatureFrom(ca))
+	assert.Equal(t, []string{
+		"example.com",
+		"web",
+		"web.app-target-ns",
+		"web.app-target-ns.svc",
+		"web.app-target-ns.svc.cluster.local",
+	}, cert.DNSNames)
+	assert.Len(t, cert.IPAddresses, 1)
+	assert.Equal(t, "10.0.0.1", cert.IPAddresses[0].String())
+
+	_, _, err = parseCertAndKey(certSecret.Data[corev1.TLSCertKey], certSecret.Data[corev1.TLSPrivateKeyKey])
+	assert.NoError(t, err)
+}
+
+func TestTLS_Renew(t *testing.T) {
+	issued := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	withNow(t, issued)
+
+	app := tlsTestApp(map[string]v1.Secret{
+		"cert": {
+			Type: "tls",
+			Params: v1.GenericMap{
+				"duration":    "48h",
+				"renewBefore": "24h",
+			},
+		},
+	})
+
+	h := tester.Harness{
+		Scheme:        scheme.Scheme,
+		ExpectedDelay: 24 * time.Hour,
+	}
+	resp, err := h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Client.Created, 1)
+	existing := resp.Client.Created[0].(*corev1.Secret)
+	existing.Name = "cert-abcde"
+	existing.UID = "1234567"
+
+	// Before the renewal window the certificate is kept
+	withNow(t, issued.Add(12*time.Hour))
+	h = tester.Harness{
+		Scheme:        scheme.Scheme,
+		Existing:      []kclient.Object{existing},
+		ExpectedDelay: 12 * time.Hour,
+	}
+	resp, err = h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Client.Created, 0)
+	assert.Len(t, resp.Client.Updated, 0)
+
+	// Inside the renewal window a new certificate is issued
+	withNow(t, issued.Add(30*time.Hour))
+	h = tester.Harness{
+		Scheme:        scheme.Scheme,
+		Existing:      []kclient.Object{existing},
+		ExpectedDelay: 24 * time.Hour,
+	}
+	resp, err = h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Client.Updated, 1)
+	renewed := resp.Client.Updated[0].(*corev1.Secret)
+	assert.NotEqual(t, existing.Data[corev1.TLSCertKey], renewed.Data[corev1.TLSCertKey])
+	assert.Equal(t, issued.Add(30*time.Hour+48*time.Hour), parseTestCert(t, renewed.Data[corev1.TLSCertKey]).NotAfter)
+}
+
+func TestKeyPair_Gen(t *testing.T) {
+	h := tester.Harness{
+		Scheme: scheme.Scheme,
+	}
+	resp, err := h.InvokeFunc(t, tlsTestApp(map[string]v1.Secret{
+		"ed": {
+			Type: "ed25519",
+		},
+		"rsa": {
+			Type: "rsa",
+			Params: v1.GenericMap{
+				"bits": int64(1024),
+			},
+		},
+		"ssh": {
+			Type: "ssh",
+		},
+		"ssh-rsa": {
+			Type: "ssh",
+			Params: v1.GenericMap{
+				"algorithm": "rsa",
+				"bits":      int64(1024),
+			},
+		},
+	}), CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, resp.Client.Created, 4)
+
+	edSecret := resp.Client.Created[0].(*corev1.Secret)
+	assert.Equal(t, v1.SecretTypeED25519, edSecret.Type)
+	block, _ := pem.Decode(edSecret.Data["key"])
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ = pem.Decode(edSecret.Data["pub"])
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, key.(ed25519.PrivateKey).Public(), pub)
+
+	rsaSecret := resp.Client.Created[1].(*corev1.Secret)
+	assert.Equal(t, v1.SecretTypeRSA, rsaSecret.Type)
+	block, _ = pem.Decode(rsaSecret.Data["key"])
+	assert.Equal(t, "PRIVATE KEY", block.Type)
+
+	for _, secret := range resp.Client.Created[2:] {
+		assert.Equal(t, v1.SecretTypeSSH, secret.(*corev1.Secret).Type)
+		data := secret.(*corev1.Secret).Data
+
+		signer, err := ssh.ParsePrivateKey(data["key"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, _, _, _, err := ssh.ParseAuthorizedKey(data["pub"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, pub.Marshal(), signer.PublicKey().Marshal())
+	}
+}
@@ -0,0 +1,44 @@
+package appdefinition
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/secretprovider"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// providerRefreshInterval is how often secrets of external providers are fetched again
+const providerRefreshInterval = 5 * time.Minute
+
+// lookupProviderSecret fetches a secret from an external provider for an app in the namespace. The secret only exists
+// in memory, its values are copied to the app namespace like any other bound secret.
+func lookupProviderSecret(ctx context.Context, req router.Request, namespace string, ref secretprovider.Reference) (*corev1.Secret, error) {
+	data, err := secretprovider.Fetch(ctx, req.Client, ref, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Annotations: map[string]string{
+				labels.AcornSecretProvider: ref.String(),
+			},
+		},
+		Data: data,
+		Type: v1.SecretTypeOpaque,
+	}, nil
+}
+
+// nextProviderRefresh returns when a secret of an external provider is fetched again
+func nextProviderRefresh(_ v1.Secret, secret *corev1.Secret) (time.Time, bool) {
+	if _, ok := secret.Annotations[labels.AcornSecretProvider]; !ok {
+		return time.Time{}, false
+	}
+	return now().Add(providerRefreshInterval), true
+}
@@ -0,0 +1,142 @@
+package appdefinition
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestProviderSecret(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "password"), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "username"), []byte("admin"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      system.ConfigName,
+			Namespace: system.Namespace,
+		},
+		Data: map[string]string{
+			"config": `{"secretProviders": ["local=file://` + dir + `,namespace=app-ns"]}`,
+		},
+	}
+
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Spec: v1.AppInstanceSpec{
+			Secrets: []v1.SecretBinding{
+				{
+					Secret: "local://db#password",
+					Target: "db",
+				},
+			},
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target-ns",
+			AppImage: v1.AppImage{
+				ID: "test",
+			},
+			AppSpec: v1.AppSpec{
+				Secrets: map[string]v1.Secret{
+					"db": {
+						Type: "opaque",
+					},
+				},
+			},
+		},
+	}
+
+	withNow(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	h := tester.Harness{
+		Scheme:        scheme.Scheme,
+		Existing:      []kclient.Object{config},
+		ExpectedDelay: providerRefreshInterval,
+	}
+	resp, err := h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, resp.Collected, 2)
+	secret := resp.Collected[0].(*corev1.Secret)
+	assert.Equal(t, "db", secret.Name)
+	assert.Equal(t, "app-target-ns", secret.Namespace)
+	assert.Equal(t, map[string][]byte{"password": []byte("secret")}, secret.Data)
+	assert.Empty(t, secret.Annotations[labels.AcornSecretProvider])
+	assert.Empty(t, resp.Client.Created)
+}
+
+func TestProviderSecretNamespaceNotAllowed(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      system.ConfigName,
+			Namespace: system.Namespace,
+		},
+		Data: map[string]string{
+			"config": `{"secretProviders": ["local=file://` + t.TempDir() + `,namespace=other-ns"]}`,
+		},
+	}
+
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Spec: v1.AppInstanceSpec{
+			Secrets: []v1.SecretBinding{
+				{
+					Secret: "local://db#password",
+					Target: "db",
+				},
+			},
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target-ns",
+			AppImage: v1.AppImage{
+				ID: "test",
+			},
+			AppSpec: v1.AppSpec{
+				Secrets: map[string]v1.Secret{
+					"db": {
+						Type: "opaque",
+					},
+				},
+			},
+		},
+	}
+
+	h := tester.Harness{
+		Scheme:   scheme.Scheme,
+		Existing: []kclient.Object{config},
+	}
+	resp, err := h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, resp.Client.Created)
+	cond := app.Status.Condition(v1.AppInstanceConditionSecrets)
+	assert.True(t, cond.Error)
+	assert.Contains(t, cond.Message, "secret provider [local] can not be used in namespace [app-ns]")
+}
@@ -0,0 +1,194 @@
+package appdefinition
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const (
+	// previousKeyPrefix is prepended to the keys of a rotated secret that hold the values from before the rotation
+	previousKeyPrefix = "previous-"
+
+	defaultRotationOverlap = time.Hour
+	maxRotationHistory     = 10
+)
+
+// rotatableSecretTypes are the secret types whose values are generated and can be rotated
+var rotatableSecretTypes = map[string]bool{
+	"token":   true,
+	"basic":   true,
+	"rsa":     true,
+	"ed25519": true,
+	"ssh":     true,
+}
+
+func rotationIntervals(rotation *v1.SecretRotation) (every time.Duration, overlap time.Duration, err error) {
+	every, err = time.ParseDuration(rotation.Every)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rotation every [%s]: %w", rotation.Every, err)
+	}
+	overlap = defaultRotationOverlap
+	if rotation.Overlap != "" {
+		overlap, err = time.ParseDuration(rotation.Overlap)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid rotation overlap [%s]: %w", rotation.Overlap, err)
+		}
+	}
+	if overlap >= every {
+		return 0, 0, fmt.Errorf("rotation overlap [%s] must be less than every [%s]", overlap, every)
+	}
+	return every, overlap, nil
+}
+
+// rotationHistory returns the times the values of the secret were generated, oldest first
+func rotationHistory(secret *corev1.Secret) (result []time.Time) {
+	for _, value := range strings.Split(secret.Annotations[labels.AcornSecretRotations], ",") {
+		t, err := time.Parse(time.RFC3339, value)
+		if err == nil {
+			result = append(result, t)
+		}
+	}
+	return
+}
+
+func setRotationHistory(secret *corev1.Secret, history []time.Time) {
+	if len(history) > maxRotationHistory {
+		history = history[len(history)-maxRotationHistory:]
+	}
+	var values []string
+	for _, t := range history {
+		values = append(values, t.UTC().Format(time.RFC3339))
+	}
+	secret.Annotations = labels.Merge(secret.Annotations, map[string]string{
+		labels.AcornSecretRotations: strings.Join(values, ","),
+	})
+}
+
+// lastRotation returns when the current values of the secret were generated
+func lastRotation(secret *corev1.Secret) time.Time {
+	if history := rotationHistory(secret); len(history) > 0 {
+		return history[len(history)-1]
+	}
+	return secret.CreationTimestamp.Time
+}
+
+func hasPreviousKeys(secret *corev1.Secret) bool {
+	for key := range secret.Data {
+		if strings.HasPrefix(key, previousKeyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func dropPreviousKeys(secret *corev1.Secret) {
+	for key := range secret.Data {
+		if strings.HasPrefix(key, previousKeyPrefix) {
+			delete(secret.Data, key)
+		}
+	}
+}
+
+// rotateSecret returns the existing secret as it should be passed to the generator, or the existing secret itself if
+// nothing changed. When a rotation is due the current values are moved to the previous keys so that the generator
+// creates new values. The previous values are dropped once the overlap window has passed.
+func rotateSecret(secretRef v1.Secret, existing *corev1.Secret) (*corev1.Secret, error) {
+	if existing == nil {
+		return nil, nil
+	}
+
+	if !rotatableSecretTypes[secretRef.Type] {
+		return existing, nil
+	}
+
+	if secretRef.Rotation == nil {
+		if !hasPreviousKeys(existing) {
+			return existing, nil
+		}
+		// Rotation was turned off
+		result := existing.DeepCopy()
+		dropPreviousKeys(result)
+		delete(result.Annotations, labels.AcornSecretRotations)
+		return result, nil
+	}
+
+	every, overlap, err := rotationIntervals(secretRef.Rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result  = existing.DeepCopy()
+		history = rotationHistory(existing)
+		last    = lastRotation(existing)
+	)
+	if last.IsZero() {
+		last = now()
+	}
+	if len(history) == 0 {
+		history = append(history, last)
+	}
+
+	if !now().Before(last.Add(every)) {
+		dropPreviousKeys(result)
+		for key, value := range existing.Data {
+			if !strings.HasPrefix(key, previousKeyPrefix) {
+				result.Data[previousKeyPrefix+key] = value
+				delete(result.Data, key)
+			}
+		}
+		history = append(history, now())
+	} else if !now().Before(last.Add(overlap)) {
+		dropPreviousKeys(result)
+	}
+
+	setRotationHistory(result, history)
+	if equality.Semantic.DeepEqual(result.Data, existing.Data) && maps.Equal(result.Annotations, existing.Annotations) {
+		return existing, nil
+	}
+	return result, nil
+}
+
+// carryRotation copies the values kept from before a rotation and the rotation history to the secret created by a
+// generator, they are maintained by rotateSecret and not by the generators
+func carryRotation(existing, secret *corev1.Secret) {
+	history, ok := existing.Annotations[labels.AcornSecretRotations]
+	if !ok {
+		return
+	}
+	for key, value := range existing.Data {
+		if strings.HasPrefix(key, previousKeyPrefix) {
+			secret.Data[key] = value
+		}
+	}
+	secret.Annotations = labels.Merge(secret.Annotations, map[string]string{
+		labels.AcornSecretRotations: history,
+	})
+}
+
+// nextRotation returns when the values of a generated secret are next rotated, or the previous values are dropped
+func nextRotation(secretRef v1.Secret, secret *corev1.Secret) (time.Time, bool) {
+	if secretRef.Rotation == nil || !rotatableSecretTypes[secretRef.Type] ||
+		secret.Labels[labels.AcornSecretGenerated] != "true" {
+		return time.Time{}, false
+	}
+	every, overlap, err := rotationIntervals(secretRef.Rotation)
+	if err != nil {
+		return time.Time{}, false
+	}
+	last := lastRotation(secret)
+	if last.IsZero() {
+		return time.Time{}, false
+	}
+	if hasPreviousKeys(secret) {
+		return last.Add(overlap), true
+	}
+	return last.Add(every), true
+}
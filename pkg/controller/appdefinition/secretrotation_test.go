@@ -0,0 +1,129 @@
+package appdefinition
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTokenRotation(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target-ns",
+			AppImage: v1.AppImage{
+				ID: "test",
+			},
+			AppSpec: v1.AppSpec{
+				Secrets: map[string]v1.Secret{
+					"pass": {
+						Type: "token",
+						Params: v1.GenericMap{
+							"length":     int64(10),
+							"characters": "abcdefghijklmnopqrstuvwxyz",
+						},
+						Rotation: &v1.SecretRotation{
+							Every:   "24h",
+							Overlap: "1h",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "pass-abcde",
+			Namespace:         "app-ns",
+			UID:               "1234567",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            acornLabelsForSecret("pass", app),
+		},
+		Data: map[string][]byte{
+			"token": []byte("oldtoken"),
+		},
+		Type: v1.SecretTypeToken,
+	}
+
+	// Not due yet, the history is recorded from the creation time
+	withNow(t, created.Add(2*time.Hour))
+	h := tester.Harness{
+		Scheme:        scheme.Scheme,
+		Existing:      []kclient.Object{existing},
+		ExpectedDelay: 22 * time.Hour,
+	}
+	resp, err := h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Client.Updated, 1)
+	existing = resp.Client.Updated[0].(*corev1.Secret)
+	assert.Equal(t, []byte("oldtoken"), existing.Data["token"])
+	assert.Equal(t, "2023-01-01T00:00:00Z", existing.Annotations[labels.AcornSecretRotations])
+
+	// Due, the old value is kept for the overlap window
+	withNow(t, created.Add(25*time.Hour))
+	h = tester.Harness{
+		Scheme:        scheme.Scheme,
+		Existing:      []kclient.Object{existing},
+		ExpectedDelay: time.Hour,
+	}
+	resp, err = h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The rotation is saved before the new value is generated
+	assert.Len(t, resp.Client.Updated, 2)
+	rotated := resp.Client.Updated[1].(*corev1.Secret)
+	assert.Len(t, rotated.Data["token"], 10)
+	assert.NotEqual(t, []byte("oldtoken"), rotated.Data["token"])
+	assert.Equal(t, []byte("oldtoken"), rotated.Data["previous-token"])
+	assert.Equal(t, "2023-01-01T00:00:00Z,2023-01-02T01:00:00Z", rotated.Annotations[labels.AcornSecretRotations])
+
+	copied := resp.Collected[0].(*corev1.Secret)
+	assert.Equal(t, rotated.Data, copied.Data)
+	assert.Equal(t, rotated.Annotations[labels.AcornSecretRotations], copied.Annotations[labels.AcornSecretRotations])
+
+	// After the overlap window the old value is dropped
+	withNow(t, created.Add(27*time.Hour))
+	h = tester.Harness{
+		Scheme:        scheme.Scheme,
+		Existing:      []kclient.Object{rotated},
+		ExpectedDelay: 22 * time.Hour,
+	}
+	resp, err = h.InvokeFunc(t, app, CreateSecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, resp.Client.Updated, 1)
+	dropped := resp.Client.Updated[0].(*corev1.Secret)
+	assert.Equal(t, rotated.Data["token"], dropped.Data["token"])
+	_, ok := dropped.Data["previous-token"]
+	assert.False(t, ok)
+}
+
+func TestRotationIntervals(t *testing.T) {
+	_, _, err := rotationIntervals(&v1.SecretRotation{Every: "1h", Overlap: "2h"})
+	assert.Error(t, err)
+
+	every, overlap, err := rotationIntervals(&v1.SecretRotation{Every: "720h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 720*time.Hour, every)
+	assert.Equal(t, defaultRotationOverlap, overlap)
+}
@@ -0,0 +1,75 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/baaah/pkg/typed"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// toSecurityContext translates the security block of a container. Escalations are only applied if they were
+// approved for the service in the app's permissions, the API server rejects apps with unapproved escalations
+// but an upgraded image could request new ones.
+func toSecurityContext(app *v1.AppInstance, serviceName string, container v1.Container) *corev1.SecurityContext {
+	security := container.Security
+	if security == nil {
+		return nil
+	}
+
+	approved := v1.FindPermission(serviceName, app.Spec.Permissions)
+	result := &corev1.SecurityContext{
+		RunAsUser:    security.RunAsUser,
+		RunAsGroup:   security.RunAsGroup,
+		RunAsNonRoot: security.RunAsNonRoot,
+	}
+
+	if security.ReadOnlyRootFilesystem {
+		result.ReadOnlyRootFilesystem = &security.ReadOnlyRootFilesystem
+	}
+	if security.Privileged && approved.HasEscalation(v1.EscalationPrivileged) {
+		result.Privileged = &security.Privileged
+	}
+
+	if security.Capabilities != nil {
+		capabilities := &corev1.Capabilities{}
+		for _, capability := range security.Capabilities.Add {
+			if approved.HasEscalation(v1.EscalationCapabilityPrefix + capability) {
+				capabilities.Add = append(capabilities.Add, corev1.Capability(capability))
+			}
+		}
+		for _, capability := range security.Capabilities.Drop {
+			capabilities.Drop = append(capabilities.Drop, corev1.Capability(capability))
+		}
+		if len(capabilities.Add) > 0 || len(capabilities.Drop) > 0 {
+			result.Capabilities = capabilities
+		}
+	}
+
+	if *result == (corev1.SecurityContext{}) {
+		return nil
+	}
+	return result
+}
+
+// applyHostNamespaces sets the approved host namespaces requested by the container or any of its sidecars on the pod
+func applyHostNamespaces(podSpec *corev1.PodSpec, app *v1.AppInstance, serviceName string, container v1.Container) {
+	approved := v1.FindPermission(serviceName, app.Spec.Permissions)
+
+	apply := func(security *v1.Security) {
+		if security == nil {
+			return
+		}
+		podSpec.HostNetwork = podSpec.HostNetwork || (security.HostNetwork && approved.HasEscalation(v1.EscalationHostNetwork))
+		podSpec.HostPID = podSpec.HostPID || (security.HostPID && approved.HasEscalation(v1.EscalationHostPID))
+		podSpec.HostIPC = podSpec.HostIPC || (security.HostIPC && approved.HasEscalation(v1.EscalationHostIPC))
+	}
+
+	apply(container.Security)
+	for _, sidecar := range typed.Sorted(container.Sidecars) {
+		apply(sidecar.Value.Security)
+	}
+
+	if podSpec.HostNetwork {
+		// Keep cluster DNS resolution for pods on the host network
+		podSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	}
+}
@@ -14,3 +14,7 @@ func TestService(t *testing.T) {
 func TestAlias(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/service/alias", DeploySpec)
 }
+
+func TestExternalService(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/service/external", DeploySpec)
+}
@@ -0,0 +1,212 @@
+package appdefinition
+
+import (
+	"strconv"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/ports"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	name2 "github.com/rancher/wrangler/pkg/name"
+	"golang.org/x/exp/slices"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isPerReplicaVolume returns true if each replica gets its own volume. A bound volume is always shared.
+func isPerReplicaVolume(appInstance *v1.AppInstance, volume string) bool {
+	volumeRequest, ok := appInstance.Status.AppSpec.Volumes[volume]
+	if !ok || !volumeRequest.PerReplica || volumeRequest.Class == v1.VolumeRequestTypeEphemeral {
+		return false
+	}
+	_, bind := isBind(appInstance, volume)
+	return !bind
+}
+
+// perReplicaVolumes returns the sorted names of the per replica volumes used by the container or its sidecars
+func perReplicaVolumes(appInstance *v1.AppInstance, container v1.Container) (result []string) {
+	volumeReferences := map[volumeReference]bool{}
+	addVolumeReferencesForContainer(appInstance, volumeReferences, container)
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		addVolumeReferencesForContainer(appInstance, volumeReferences, entry.Value)
+	}
+
+	volumes := map[string]bool{}
+	for volume := range volumeReferences {
+		if volume.name != "" && isPerReplicaVolume(appInstance, volume.name) {
+			volumes[volume.name] = true
+		}
+	}
+	return typed.SortedKeys(volumes)
+}
+
+// isPerReplica returns true if the container is run as a StatefulSet because it uses per replica volumes
+func isPerReplica(appInstance *v1.AppInstance, container v1.Container) bool {
+	return len(perReplicaVolumes(appInstance, container)) > 0
+}
+
+// perReplicaCount returns the number of replicas that get a volume. The volumes are kept while the app is stopped.
+func perReplicaCount(appInstance *v1.AppInstance, container v1.Container) int {
+	if isStateful(appInstance, container) || container.Scale == nil {
+		return 1
+	}
+	return int(*container.Scale)
+}
+
+// perReplicaPVCName is the name the StatefulSet controller uses for the claim of a replica
+func perReplicaPVCName(volume, containerName string, replica int) string {
+	return volume + "-" + perReplicaPodName(containerName, replica)
+}
+
+func perReplicaPodName(containerName string, replica int) string {
+	return containerName + "-" + strconv.Itoa(replica)
+}
+
+// toPerReplicaPVCs returns the claims of every replica of the containers using the volume. They are created before
+// the StatefulSet creates them from its templates, so that they can be bound to the volumes of a previous run of the
+// app, like the claims of shared volumes.
+func toPerReplicaPVCs(req router.Request, appInstance *v1.AppInstance, volume string) (result []kclient.Object, _ error) {
+	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Containers) {
+		containerName, container := entry.Key, entry.Value
+		if ports.IsLinked(appInstance, containerName) {
+			continue
+		}
+		if !slices.Contains(perReplicaVolumes(appInstance, container), volume) {
+			continue
+		}
+
+		for i := 0; i < perReplicaCount(appInstance, container); i++ {
+			pvc := toPVC(appInstance, volume)
+			pvc.Name = perReplicaPVCName(volume, containerName, i)
+			pvc.Labels[labels.AcornContainerName] = containerName
+			pvc.Labels[labels.AcornContainerReplica] = perReplicaPodName(containerName, i)
+
+			pvName, err := lookupExistingPV(req, appInstance, pvc.Name)
+			if err != nil {
+				return nil, err
+			}
+			pvc.Spec.VolumeName = pvName
+
+			result = append(result, pvc)
+		}
+	}
+	return
+}
+
+func headlessServiceName(name string) string {
+	return name2.SafeConcatName(name, "headless")
+}
+
+// toStatefulSet runs the pods of the deployment as a StatefulSet that claims a volume for each replica from the per
+// replica volumes of the container
+func toStatefulSet(appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) *appsv1.StatefulSet {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: dep.ObjectMeta,
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    dep.Spec.Replicas,
+			Selector:    dep.Spec.Selector,
+			Template:    *dep.Spec.Template.DeepCopy(),
+			ServiceName: headlessServiceName(dep.Name),
+		},
+	}
+	// Each pod gets its own name as hostname from the StatefulSet
+	sts.Spec.Template.Spec.Hostname = ""
+
+	for _, volume := range perReplicaVolumes(appInstance, container) {
+		sts.Spec.VolumeClaimTemplates = append(sts.Spec.VolumeClaimTemplates, toClaimTemplate(volume, dep.Name))
+	}
+
+	return sts
+}
+
+// toClaimTemplate returns the template the StatefulSet controller names the claim of each replica after. The claims
+// are created by toPerReplicaPVCs before the StatefulSet is scaled up, and the templates of a StatefulSet can not be
+// changed, so the template only has values that do not change with the app. The size is the minimum to keep
+// resizing the volumes possible.
+func toClaimTemplate(volume, containerName string) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: volume,
+			Labels: map[string]string{
+				labels.AcornContainerName: containerName,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *v1.MinSize,
+				},
+			},
+		},
+	}
+}
+
+// holdStatefulSet returns true if the StatefulSet must not be created or updated yet. The claims of the replicas
+// must exist first, otherwise the StatefulSet controller creates them from the templates. A StatefulSet with
+// different claim templates is deleted, leaving its pods running, because the templates can not be updated. It is
+// created again once it is gone and adopts the pods.
+func holdStatefulSet(req router.Request, appInstance *v1.AppInstance, sts *appsv1.StatefulSet, container v1.Container) (bool, error) {
+	for _, template := range sts.Spec.VolumeClaimTemplates {
+		for i := 0; i < perReplicaCount(appInstance, container); i++ {
+			err := req.Get(&corev1.PersistentVolumeClaim{}, sts.Namespace, perReplicaPVCName(template.Name, sts.Name, i))
+			if apierror.IsNotFound(err) {
+				return true, nil
+			} else if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	existing := &appsv1.StatefulSet{}
+	if err := req.Get(existing, sts.Namespace, sts.Name); apierror.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if !existing.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+	if claimTemplatesEqual(existing.Spec.VolumeClaimTemplates, sts.Spec.VolumeClaimTemplates) {
+		return false, nil
+	}
+	return true, req.Client.Delete(req.Ctx, existing, kclient.PropagationPolicy(metav1.DeletePropagationOrphan))
+}
+
+func claimTemplatesEqual(existing, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for i := range existing {
+		if existing[i].Name != desired[i].Name ||
+			!equality.Semantic.DeepEqual(existing[i].Labels, desired[i].Labels) ||
+			!equality.Semantic.DeepEqual(existing[i].Annotations, desired[i].Annotations) ||
+			!equality.Semantic.DeepEqual(existing[i].Spec.StorageClassName, desired[i].Spec.StorageClassName) ||
+			!equality.Semantic.DeepEqual(existing[i].Spec.AccessModes, desired[i].Spec.AccessModes) {
+			return false
+		}
+	}
+	return true
+}
+
+// toHeadlessService returns the governing service of the StatefulSet which gives each replica a stable DNS name
+func toHeadlessService(dep *appsv1.Deployment) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      headlessServiceName(dep.Name),
+			Namespace: dep.Namespace,
+			Labels:    dep.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  dep.Spec.Selector.MatchLabels,
+			// Replicas of a cluster usually have to find each other before they are ready
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/acorn-io/acorn/pkg/config"
 	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/ports"
+	"github.com/acorn-io/acorn/pkg/publish"
 	"github.com/acorn-io/baaah/pkg/merr"
 	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/typed"
+	"golang.org/x/exp/maps"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -66,7 +68,12 @@ func ReadyStatus(req router.Request, resp router.Response) error {
 		}
 	}
 	for _, v := range app.Status.JobsStatus {
-		if !v.Succeed {
+		if !v.Succeed && !v.Skipped {
+			ready = false
+		}
+	}
+	for _, v := range app.Status.AcornStatus {
+		if !v.Created || !v.Ready {
 			ready = false
 		}
 	}
@@ -76,6 +83,7 @@ func ReadyStatus(req router.Request, resp router.Response) error {
 		app.Status.Condition(v1.AppInstanceConditionParsed).Success &&
 		app.Status.Condition(v1.AppInstanceConditionContainers).Success &&
 		app.Status.Condition(v1.AppInstanceConditionJobs).Success &&
+		app.Status.Condition(v1.AppInstanceConditionAcorns).Success &&
 		app.Status.Condition(v1.AppInstanceConditionSecrets).Success &&
 		app.Status.Condition(v1.AppInstanceConditionPulled).Success &&
 		app.Status.Condition(v1.AppInstanceConditionController).Success &&
@@ -99,9 +107,14 @@ func JobStatus(req router.Request, resp router.Response) error {
 		return err
 	}
 
+	event := jobEvent(app)
+	oldStatus := app.Status.JobsStatus
 	app.Status.JobsStatus = map[string]v1.JobStatus{}
-	for jobName := range app.Status.AppSpec.Jobs {
-		app.Status.JobsStatus[jobName] = v1.JobStatus{}
+	for jobName, job := range app.Status.AppSpec.Jobs {
+		app.Status.JobsStatus[jobName] = v1.JobStatus{
+			Skipped: !runsOnEvent(job, event),
+			Events:  oldStatus[jobName].Events,
+		}
 	}
 
 	var (
@@ -131,9 +144,8 @@ func JobStatus(req router.Request, resp router.Response) error {
 		for _, message := range messages {
 			messageSet.Insert(message...)
 		}
-		jobStatus := v1.JobStatus{
-			Message: strings.Join(messageSet.List(), "; "),
-		}
+		jobStatus := app.Status.JobsStatus[job.Name]
+		jobStatus.Message = strings.Join(messageSet.List(), "; ")
 		if job.Status.Active > 0 {
 			jobStatus.Running = true
 			running = true
@@ -146,6 +158,18 @@ func JobStatus(req router.Request, resp router.Response) error {
 			failed = true
 			failedName = job.Name
 		}
+		if runEvent := job.Labels[labels.AcornJobEvent]; runEvent != "" {
+			jobStatus.Events = maps.Clone(jobStatus.Events)
+			if jobStatus.Events == nil {
+				jobStatus.Events = map[string]v1.JobEventStatus{}
+			}
+			jobStatus.Events[runEvent] = v1.JobEventStatus{
+				Succeed: jobStatus.Succeed,
+				Failed:  jobStatus.Failed,
+				Running: jobStatus.Running,
+				Message: jobStatus.Message,
+			}
+		}
 		app.Status.JobsStatus[job.Name] = jobStatus
 	}
 
@@ -208,11 +232,23 @@ func podsStatus(req router.Request, namespace string, sel klabels.Selector) (boo
 	return isTransition, messages, nil
 }
 
+// workloadStatus is the status of the Deployment or StatefulSet of a container
+type workloadStatus struct {
+	metav1.ObjectMeta
+	replicas        *int32
+	readyReplicas   int32
+	currentReplicas int32
+	updatedReplicas int32
+	stalled         bool
+}
+
 func AppStatus(req router.Request, resp router.Response) error {
 	var (
-		app  = req.Object.(*v1.AppInstance)
-		cond = condition.Setter(app, resp, v1.AppInstanceConditionContainers)
-		deps = &appsv1.DeploymentList{}
+		app          = req.Object.(*v1.AppInstance)
+		cond         = condition.Setter(app, resp, v1.AppInstanceConditionContainers)
+		rolloutCond  = condition.Setter(app, resp, v1.AppInstanceConditionRollout)
+		deps         = &appsv1.DeploymentList{}
+		statefulSets = &appsv1.StatefulSetList{}
 	)
 
 	cfg, err := config.Get(req.Ctx, req.Client)
@@ -220,14 +256,17 @@ func AppStatus(req router.Request, resp router.Response) error {
 		return err
 	}
 
-	err = req.List(deps, &kclient.ListOptions{
+	listOpts := &kclient.ListOptions{
 		Namespace: app.Status.Namespace,
 		LabelSelector: klabels.SelectorFromSet(map[string]string{
 			labels.AcornManaged: "true",
 			labels.AcornAppName: app.Name,
 		}),
-	})
-	if err != nil {
+	}
+	if err := req.List(deps, listOpts); err != nil {
+		return err
+	}
+	if err := req.List(statefulSets, listOpts); err != nil {
 		return err
 	}
 
@@ -244,29 +283,65 @@ func AppStatus(req router.Request, resp router.Response) error {
 		return err
 	}
 
-	var messages []string
+	var (
+		messages    []string
+		stalled     []string
+		unsupported []string
+	)
 
 	container := map[string]v1.ContainerStatus{}
-	for dep := range app.Status.AppSpec.Containers {
+	for dep, spec := range app.Status.AppSpec.Containers {
 		container[dep] = v1.ContainerStatus{
 			Created: ports.IsLinked(app, dep),
 		}
+		if isAutoscaleUnsupported(app, spec) {
+			unsupported = append(unsupported, dep)
+		}
 	}
 
-	for _, dep := range deps.Items {
+	var workloads []workloadStatus
+	for i := range deps.Items {
+		dep := &deps.Items[i]
+		workloads = append(workloads, workloadStatus{
+			ObjectMeta:      dep.ObjectMeta,
+			replicas:        dep.Spec.Replicas,
+			readyReplicas:   dep.Status.ReadyReplicas,
+			currentReplicas: dep.Status.Replicas,
+			updatedReplicas: dep.Status.UpdatedReplicas,
+			stalled:         isRolloutStalled(dep),
+		})
+	}
+	for _, set := range statefulSets.Items {
+		workloads = append(workloads, workloadStatus{
+			ObjectMeta:      set.ObjectMeta,
+			replicas:        set.Spec.Replicas,
+			readyReplicas:   set.Status.ReadyReplicas,
+			currentReplicas: set.Status.Replicas,
+			updatedReplicas: set.Status.UpdatedReplicas,
+		})
+	}
+
+	for _, dep := range workloads {
 		containerName := dep.Labels[labels.AcornContainerName]
 		if containerName == "" {
 			continue
 		}
 
 		status := container[containerName]
-		status.Ready = dep.Status.ReadyReplicas
-		status.ReadyDesired = dep.Status.Replicas
-		status.UpToDate = dep.Status.UpdatedReplicas
+		status.Ready = dep.readyReplicas
+		status.ReadyDesired = dep.currentReplicas
+		status.UpToDate = dep.updatedReplicas
+		status.CurrentReplicas = dep.currentReplicas
+		if dep.replicas != nil {
+			status.DesiredReplicas = *dep.replicas
+		}
 		status.Created = true
 		container[containerName] = status
 
-		if podMessage := podMessages[containerName]; len(podMessage) > 0 {
+		if dep.stalled {
+			// Reported by the rollout condition, the containers won't finish updating without a change to the app
+			stalled = append(stalled, containerName)
+		} else if podMessage := podMessages[containerName]; len(podMessage) > 0 {
 			messages = append(messages, podMessage...)
 		} else if dep.Annotations[labels.AcornAppGeneration] != strconv.Itoa(int(app.Generation)) {
 			isTransition = true
@@ -288,7 +363,17 @@ func AppStatus(req router.Request, resp router.Response) error {
 		return err
 	}
 
-	if isTransition {
+	if len(stalled) > 0 {
+		sort.Strings(stalled)
+		rolloutCond.Error(fmt.Errorf("rollout of %s exceeded its progress deadline", strings.Join(stalled, ", ")))
+	} else {
+		rolloutCond.Success()
+	}
+
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		cond.Error(fmt.Errorf("scale of %s can not be a range because the replicas would not share their volumes", strings.Join(unsupported, ", ")))
+	} else if isTransition {
 		// dedup, sort
 		messages := sets.NewString(messages...).List()
 		cond.Unknown(strings.TrimSpace(strings.Join(messages, "; ")))
@@ -304,6 +389,12 @@ func AppStatus(req router.Request, resp router.Response) error {
 				break
 			}
 		}
+		for _, v := range app.Status.AcornStatus {
+			if v.Created && !v.Stopped {
+				allZero = false
+				break
+			}
+		}
 		if allZero {
 			app.Status.Stopped = true
 		}
@@ -344,7 +435,7 @@ func podName(pod *corev1.Pod) string {
 	return pod.Labels[labels.AcornContainerName]
 }
 
-func IngressTLSHosts(ctx context.Context, client kclient.Client, app *v1.AppInstance) (map[string]interface{}, error) {
+func IngressTLSHosts(ctx context.Context, client kclient.Client, cfg *apiv1.Config, app *v1.AppInstance) (map[string]interface{}, error) {
 	ingresses := &networkingv1.IngressList{}
 	err := client.List(ctx, ingresses, &kclient.ListOptions{
 		Namespace: app.Status.Namespace,
@@ -368,6 +459,31 @@ func IngressTLSHosts(ctx context.Context, client kclient.Client, app *v1.AppInst
 		}
 	}
 
+	if _, _, ok := publish.GatewayRef(cfg); !ok {
+		return ingressTLSHosts, nil
+	}
+
+	// The hosts of apps published through a gateway that have a TLS certificate are recorded on their HTTPRoutes
+	routes := publish.NewHTTPRouteList()
+	err = client.List(ctx, routes, &kclient.ListOptions{
+		Namespace: app.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+			labels.AcornAppName: app.Name,
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, route := range routes.Items {
+		if hosts := route.GetAnnotations()[labels.AcornTLSHosts]; hosts != "" {
+			for _, host := range strings.Split(hosts, ",") {
+				ingressTLSHosts[host] = nil
+			}
+		}
+	}
+
 	return ingressTLSHosts, nil
 }
 
@@ -378,7 +494,7 @@ func endpoints(req router.Request, cfg *apiv1.Config, app *v1.AppInstance) (stri
 		endpointTarget[target] = append(endpointTarget[target], endpoint)
 	}
 
-	ingressTLSHosts, err := IngressTLSHosts(req.Ctx, req.Client, app)
+	ingressTLSHosts, err := IngressTLSHosts(req.Ctx, req.Client, cfg, app)
 	if err != nil {
 		return "", err
 	}
@@ -394,8 +510,8 @@ func endpoints(req router.Request, cfg *apiv1.Config, app *v1.AppInstance) (stri
 		for _, endpoint := range endpoints {
 			buf := &strings.Builder{}
 			switch endpoint.Protocol {
-			case v1.ProtocolHTTP:
-				if !strings.HasPrefix(endpoint.Address, "http") {
+			case v1.ProtocolHTTP, v1.ProtocolHTTP2, v1.ProtocolGRPC:
+				if !strings.HasPrefix(endpoint.Address, "http") && !strings.HasPrefix(endpoint.Address, "grpc") {
 					var host string
 					a, b, ok := strings.Cut(endpoint.Address, "://")
 					if ok {
@@ -403,11 +519,9 @@ func endpoints(req router.Request, cfg *apiv1.Config, app *v1.AppInstance) (stri
 					} else {
 						host, _, _ = strings.Cut(a, ":")
 					}
-					if _, ok := ingressTLSHosts[host]; ok {
-						buf.WriteString("https://")
-					} else {
-						buf.WriteString("http://")
-					}
+					_, tls := ingressTLSHosts[host]
+					buf.WriteString(string(endpoint.Protocol.PublishProtocol(tls)))
+					buf.WriteString("://")
 				}
 			default:
 				buf.WriteString(strings.ToLower(string(endpoint.Protocol)))
@@ -415,7 +529,7 @@ func endpoints(req router.Request, cfg *apiv1.Config, app *v1.AppInstance) (stri
 			}
 
 			if endpoint.Pending {
-				if endpoint.Protocol == "http" {
+				if endpoint.Protocol.IsHTTP() {
 					buf.WriteString("<Pending Ingress>")
 				} else {
 					buf.WriteString("<Pending Load Balancer>")
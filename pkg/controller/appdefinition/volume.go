@@ -22,6 +22,8 @@ import (
 const (
 	AcornHelper     = " /acorn-helper"
 	AcornHelperPath = "/.acorn"
+	// AcornSeed is the volume the acorn binary is copied to for the init containers that seed volumes
+	AcornSeed = " /acorn-seed"
 )
 
 func addPVCs(req router.Request, appInstance *v1.AppInstance, resp router.Response) error {
@@ -85,70 +87,97 @@ func toPVCs(req router.Request, appInstance *v1.AppInstance) (result []kclient.O
 	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Volumes) {
 		volume, volumeRequest := entry.Key, entry.Value
 
-		var (
-			accessModes         = translateAccessModes(volumeRequest.AccessModes)
-			volumeBinding, bind = isBind(appInstance, volume)
-			class               *string
-		)
-
+		_, bind := isBind(appInstance, volume)
 		if volumeRequest.Class == v1.VolumeRequestTypeEphemeral && !bind {
 			continue
 		}
 
-		pvc := corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      volume,
-				Namespace: appInstance.Status.Namespace,
-				Labels:    volumeLabels(appInstance, volume, volumeRequest),
-				Annotations: labels.GatherScoped(volume, v1.LabelTypeVolume, appInstance.Status.AppSpec.Annotations,
-					volumeRequest.Annotations, appInstance.Spec.Annotations),
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: accessModes,
-				Resources: corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{},
-				},
-			},
-		}
-
-		if bind {
-			pvc.Name = bindName(volume)
-			pvc.Spec.VolumeName = volumeBinding.Volume
-			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MinSize
-		} else {
-			if volumeRequest.Class != "" {
-				class = &volumeRequest.Class
+		if isPerReplicaVolume(appInstance, volume) {
+			pvcs, err := toPerReplicaPVCs(req, appInstance, volume)
+			if err != nil {
+				return nil, err
 			}
-			pvc.Spec.StorageClassName = class
+			result = append(result, pvcs...)
+			continue
+		}
 
+		pvc := toPVC(appInstance, volume)
+		if !bind {
 			pvName, err := lookupExistingPV(req, appInstance, volume)
 			if err != nil {
 				return nil, err
 			}
 			pvc.Spec.VolumeName = pvName
-
-			if volumeRequest.Size == "" {
-				pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.DefaultSize
-			} else {
-				pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeRequest.Size)
-			}
 		}
 
-		if len(volumeBinding.AccessModes) > 0 {
-			pvc.Spec.AccessModes = translateAccessModes(volumeBinding.AccessModes)
-		}
+		result = append(result, pvc)
+	}
+	return
+}
 
-		if volumeBinding.Class != "" {
-			pvc.Spec.StorageClassName = &volumeBinding.Class
+// toPVC returns the claim for the volume. A bound volume claims the existing volume, otherwise the claim is for a new
+// volume or the volume that is found by lookupExistingPV.
+func toPVC(appInstance *v1.AppInstance, volume string) *corev1.PersistentVolumeClaim {
+	var (
+		volumeRequest       = appInstance.Status.AppSpec.Volumes[volume]
+		accessModes         = translateAccessModes(volumeRequest.AccessModes)
+		volumeBinding, bind = isBind(appInstance, volume)
+		class               *string
+	)
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volume,
+			Namespace: appInstance.Status.Namespace,
+			Labels:    volumeLabels(appInstance, volume, volumeRequest),
+			Annotations: labels.GatherScoped(volume, v1.LabelTypeVolume, appInstance.Status.AppSpec.Annotations,
+				volumeRequest.Annotations, appInstance.Spec.Annotations),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{},
+			},
+		},
+	}
+
+	if bind {
+		pvc.Name = bindName(volume)
+		pvc.Spec.VolumeName = volumeBinding.Volume
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MinSize
+	} else {
+		if volumeRequest.Class != "" {
+			class = &volumeRequest.Class
 		}
+		pvc.Spec.StorageClassName = class
 
-		if volumeBinding.Size != "" {
-			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeBinding.Size)
+		if volumeRequest.Size == "" {
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.DefaultSize
+		} else {
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeRequest.Size)
 		}
+	}
 
-		result = append(result, &pvc)
+	if len(volumeBinding.AccessModes) > 0 {
+		pvc.Spec.AccessModes = translateAccessModes(volumeBinding.AccessModes)
 	}
-	return
+
+	if volumeBinding.Class != "" {
+		pvc.Spec.StorageClassName = &volumeBinding.Class
+	}
+
+	if volumeBinding.Size != "" {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeBinding.Size)
+	}
+
+	if seed := volumeRequest.Seed; seed != nil && seed.Image != "" {
+		if pvc.Annotations == nil {
+			pvc.Annotations = map[string]string{}
+		}
+		pvc.Annotations[labels.AcornVolumeSeed] = seed.Image
+	}
+
+	return pvc
 }
 
 func volumeLabels(appInstance *v1.AppInstance, volume string, volumeRequest v1.VolumeRequest) map[string]string {
@@ -176,12 +205,7 @@ func isEphemeral(appInstance *v1.AppInstance, volume string) (v1.VolumeRequest,
 }
 
 func isBind(appInstance *v1.AppInstance, volume string) (v1.VolumeBinding, bool) {
-	for _, v := range appInstance.Spec.Volumes {
-		if v.Target == volume {
-			return v, v.Volume != ""
-		}
-	}
-	return v1.VolumeBinding{}, false
+	return appInstance.Spec.GetVolumeBinding(volume)
 }
 
 func bindName(volume string) string {
@@ -195,6 +219,26 @@ func toVolumeName(appInstance *v1.AppInstance, volume string) (string, bool) {
 	return volume, false
 }
 
+// seededVolumes returns the sorted names of the volumes used by the container or its sidecars that have a seed
+func seededVolumes(app *v1.AppInstance, container v1.Container) (result []string) {
+	volumeReferences := map[volumeReference]bool{}
+	addVolumeReferencesForContainer(app, volumeReferences, container)
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		addVolumeReferencesForContainer(app, volumeReferences, entry.Value)
+	}
+
+	for volume := range volumeReferences {
+		if volume.name == "" {
+			continue
+		}
+		if seed := app.Status.AppSpec.Volumes[volume.name].Seed; seed != nil && seed.Image != "" {
+			result = append(result, volume.name)
+		}
+	}
+	sort.Strings(result)
+	return
+}
+
 func addVolumeReferencesForContainer(app *v1.AppInstance, volumeReferences map[volumeReference]bool, container v1.Container) {
 	for _, entry := range typed.Sorted(container.Dirs) {
 		volume := entry.Value
@@ -246,17 +290,6 @@ func (v volumeReference) ParseMode() (*int32, error) {
 	return toMode(v.mode)
 }
 
-func getFilesFileModesForApp(app *v1.AppInstance) map[string]bool {
-	fileModes := map[string]bool{}
-	for _, container := range app.Status.AppSpec.Containers {
-		addFilesFileModesForContainer(fileModes, container)
-	}
-	for _, container := range app.Status.AppSpec.Jobs {
-		addFilesFileModesForContainer(fileModes, container)
-	}
-	return fileModes
-}
-
 func normalizeMode(mode string) string {
 	if mode == "0644" || mode == "644" {
 		return ""
@@ -306,14 +339,22 @@ func toVolumes(appInstance *v1.AppInstance, container v1.Container) (result []co
 
 		name, bind := toVolumeName(appInstance, volume.name)
 		if vr, ok := isEphemeral(appInstance, volume.name); ok && !bind {
+			var medium corev1.StorageMedium
+			if vr.Medium == v1.VolumeMediumMemory {
+				medium = corev1.StorageMediumMemory
+			}
 			result = append(result, corev1.Volume{
 				Name: sanitizeVolumeName(volume.name),
 				VolumeSource: corev1.VolumeSource{
 					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium:    medium,
 						SizeLimit: v1.MustParseResourceQuantity(vr.Size),
 					},
 				},
 			})
+		} else if isPerReplicaVolume(appInstance, volume.name) {
+			// The volume of each replica comes from the volumeClaimTemplates of the StatefulSet
+			continue
 		} else {
 			result = append(result, corev1.Volume{
 				Name: volume.name,
@@ -326,9 +367,27 @@ func toVolumes(appInstance *v1.AppInstance, container v1.Container) (result []co
 		}
 	}
 
+	if len(seededVolumes(appInstance, container)) > 0 {
+		result = append(result, corev1.Volume{
+			Name: sanitizeVolumeName(AcornSeed),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	fileModes := map[string]bool{}
 	addFilesFileModesForContainer(fileModes, container)
 
+	var fileObjects []*fileObject
+	if len(fileModes) > 0 {
+		objects, err := toFileObjects(appInstance)
+		if err != nil {
+			return nil, err
+		}
+		fileObjects = objects
+	}
+
 	for _, modeString := range typed.SortedKeys(fileModes) {
 		name := "files"
 		var (
@@ -342,17 +401,13 @@ func toVolumes(appInstance *v1.AppInstance, container v1.Container) (result []co
 				return nil, err
 			}
 		}
-		result = append(result, corev1.Volume{
-			Name: name,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					DefaultMode: mode,
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: name,
-					},
-				},
-			},
-		})
+		var objects []*fileObject
+		for _, obj := range fileObjects {
+			if obj.mode == modeString {
+				objects = append(objects, obj)
+			}
+		}
+		result = append(result, toFilesVolume(name, mode, objects))
 	}
 
 	sort.Slice(result, func(i, j int) bool {
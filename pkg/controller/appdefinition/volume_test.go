@@ -1,6 +1,7 @@
 package appdefinition
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,10 +10,15 @@ import (
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/router/tester"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestVolumeController(t *testing.T) {
@@ -167,3 +173,75 @@ func TestVolumeLabelsAnnotations(t *testing.T) {
 	assert.Contains(t, pvc2.Annotations, "globalfromacornfilea")
 	assert.NotContains(t, pvc2.Annotations, "vol1fromacornfilea")
 }
+
+func TestHoldStatefulSet(t *testing.T) {
+	app := &v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-created-namespace",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"db": {
+						Scale: &[]int32{2}[0],
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/db": {
+								Volume: "data",
+							},
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data": {
+						PerReplica: true,
+					},
+				},
+			},
+		},
+	}
+	container := app.Status.AppSpec.Containers["db"]
+	sts := toStatefulSet(app, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "app-created-namespace",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: container.Scale,
+		},
+	}, container)
+
+	claim := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "app-created-namespace",
+			},
+		}
+	}
+	hold := func(objs ...kclient.Object) (bool, router.Request) {
+		req := router.Request{
+			Ctx:    context.Background(),
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build(),
+		}
+		hold, err := holdStatefulSet(req, app, sts, container)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hold, req
+	}
+
+	// The claim of the second replica is missing
+	held, _ := hold(claim("data-db-0"))
+	assert.True(t, held)
+
+	held, _ = hold(claim("data-db-0"), claim("data-db-1"))
+	assert.False(t, held)
+
+	held, _ = hold(claim("data-db-0"), claim("data-db-1"), sts.DeepCopy())
+	assert.False(t, held)
+
+	// A StatefulSet with other claim templates is deleted to be created again
+	existing := sts.DeepCopy()
+	existing.Spec.VolumeClaimTemplates[0].Labels[labels.AcornAppName] = "app-name"
+	held, req := hold(claim("data-db-0"), claim("data-db-1"), existing)
+	assert.True(t, held)
+	assert.True(t, apierror.IsNotFound(req.Get(&appsv1.StatefulSet{}, "app-created-namespace", "db")))
+}
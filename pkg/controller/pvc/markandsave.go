@@ -26,6 +26,7 @@ func MarkAndSave(req router.Request, resp router.Response) error {
 	if pv.Labels[labels.AcornAppName] != pvc.Labels[labels.AcornAppName] ||
 		pv.Labels[labels.AcornAppNamespace] != pvc.Labels[labels.AcornAppNamespace] ||
 		pv.Labels[labels.AcornVolumeName] != pvc.Name ||
+		pv.Labels[labels.AcornContainerReplica] != pvc.Labels[labels.AcornContainerReplica] ||
 		pv.Labels[labels.AcornManaged] != "true" ||
 		pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
 		if pv.Labels == nil {
@@ -35,6 +36,12 @@ func MarkAndSave(req router.Request, resp router.Response) error {
 		pv.Labels[labels.AcornVolumeName] = pvc.Name
 		pv.Labels[labels.AcornAppName] = pvc.Labels[labels.AcornAppName]
 		pv.Labels[labels.AcornAppNamespace] = pvc.Labels[labels.AcornAppNamespace]
+		if replica := pvc.Labels[labels.AcornContainerReplica]; replica != "" {
+			// The volume of one replica of a container with per replica volumes
+			pv.Labels[labels.AcornContainerReplica] = replica
+		} else {
+			delete(pv.Labels, labels.AcornContainerReplica)
+		}
 		pv.Labels[labels.AcornManaged] = "true"
 		pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
 		return req.Client.Update(req.Ctx, &pv)
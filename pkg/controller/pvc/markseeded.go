@@ -0,0 +1,66 @@
+package pvc
+
+import (
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MarkSeeded annotates the claim with labels.AcornVolumeSeeded once an init container of the pod copied a seed into
+// its volume. The state is kept on the claim instead of in the volume, so that the app's data is never touched.
+func MarkSeeded(req router.Request, resp router.Response) error {
+	pod := req.Object.(*corev1.Pod)
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.State.Terminated == nil || status.State.Terminated.ExitCode != 0 {
+			continue
+		}
+		claimName := seededClaimName(pod, status.Name)
+		if claimName == "" {
+			continue
+		}
+		if err := markSeeded(req, pod.Namespace, claimName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seededClaimName returns the claim the init container seeds, if it is one that seeds a volume
+func seededClaimName(pod *corev1.Pod, containerName string) string {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != containerName {
+			continue
+		}
+		for _, mount := range container.VolumeMounts {
+			if mount.MountPath != volumebackup.SeedDataPath {
+				continue
+			}
+			for _, volume := range pod.Spec.Volumes {
+				if volume.Name == mount.Name && volume.PersistentVolumeClaim != nil {
+					return volume.PersistentVolumeClaim.ClaimName
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func markSeeded(req router.Request, namespace, claimName string) error {
+	var pvc corev1.PersistentVolumeClaim
+	if err := req.Get(&pvc, namespace, claimName); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if pvc.Annotations[labels.AcornVolumeSeeded] == "true" {
+		return nil
+	}
+
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[labels.AcornVolumeSeeded] = "true"
+	return req.Client.Update(req.Ctx, &pvc)
+}
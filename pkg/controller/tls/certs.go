@@ -137,7 +137,7 @@ func ProvisionCerts(req router.Request, resp router.Response) error {
 	var errs []error
 
 	for i, ep := range appInstance.Status.Endpoints {
-		if ep.Protocol != "http" {
+		if !ep.Protocol.IsHTTP() {
 			continue
 		}
 
@@ -145,7 +145,7 @@ func ProvisionCerts(req router.Request, resp router.Response) error {
 			return err
 		}
 		provisionedCerts[ep.Address] = nil
-		ep.PublishProtocol = v1.PublishProtocolHTTPS
+		ep.PublishProtocol = ep.Protocol.PublishProtocol(true)
 		appInstance.Status.Endpoints[i] = ep
 	}
 
@@ -0,0 +1,222 @@
+package volumebackup
+
+import (
+	"fmt"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/condition"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Finalizer removes the archive of a backup that was uploaded to S3
+const Finalizer = "volumebackups.acorn.io/delete"
+
+// BackupVolume takes the backup, either as a VolumeSnapshot or with a Job that uploads an archive of the volume. The
+// snapshot is kept for as long as the backup exists, the Job is removed once the upload finished.
+func BackupVolume(req router.Request, resp router.Response) error {
+	backup := req.Object.(*v1.VolumeBackupInstance)
+	cond := condition.Setter(backup, resp, v1.VolumeBackupInstanceConditionBackup)
+
+	if backup.Status.Method == "" {
+		if err := startBackup(req, backup); err != nil {
+			cond.Error(err)
+			return nil
+		}
+	}
+
+	var err error
+	switch backup.Status.Method {
+	case v1.VolumeBackupMethodSnapshot:
+		err = snapshot(req, resp, backup, cond)
+	case v1.VolumeBackupMethodS3:
+		err = upload(req, resp, backup, cond)
+	}
+	if err != nil {
+		cond.Error(err)
+	}
+	return err
+}
+
+// startBackup records the claim the volume is bound to and how the backup is taken. This is only done once so that
+// a backup does not change if the volume or the configuration changes while it runs.
+func startBackup(req router.Request, backup *v1.VolumeBackupInstance) error {
+	pv := &corev1.PersistentVolume{}
+	if err := req.Get(pv, "", backup.Spec.Volume); apierrors.IsNotFound(err) {
+		return fmt.Errorf("volume [%s] not found", backup.Spec.Volume)
+	} else if err != nil {
+		return err
+	}
+
+	if pv.Labels[labels.AcornAppNamespace] != backup.Namespace {
+		return fmt.Errorf("volume [%s] not found", backup.Spec.Volume)
+	}
+	if pv.Spec.ClaimRef == nil || pv.Status.Phase != corev1.VolumeBound {
+		return fmt.Errorf("volume [%s] is not bound to an app", backup.Spec.Volume)
+	}
+
+	cfg, err := config.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	status := v1.VolumeBackupInstanceStatus{
+		AppName:    pv.Labels[labels.AcornAppName],
+		VolumeName: pv.Labels[labels.AcornVolumeName],
+		Namespace:  pv.Spec.ClaimRef.Namespace,
+		ClaimName:  pv.Spec.ClaimRef.Name,
+	}
+
+	switch {
+	case cfg.VolumeSnapshotClass != nil && *cfg.VolumeSnapshotClass != "":
+		status.Method = v1.VolumeBackupMethodSnapshot
+		status.Location = status.Namespace + "/" + volumebackup.SnapshotName(backup)
+	case cfg.VolumeBackupURL != nil && *cfg.VolumeBackupURL != "":
+		target, err := volumebackup.GetTarget(req.Ctx, req.Client)
+		if err != nil {
+			return err
+		}
+		status.Method = v1.VolumeBackupMethodS3
+		status.Location = target.URL(target.Key(backup.Namespace, backup.Name)).String()
+	default:
+		return fmt.Errorf("volume backups are not configured, set --volume-snapshot-class or --volume-backup-url with acorn install")
+	}
+
+	status.Conditions = backup.Status.Conditions
+	backup.Status = status
+	return nil
+}
+
+func snapshot(req router.Request, resp router.Response, backup *v1.VolumeBackupInstance, cond *condition.Callback) error {
+	existing := volumebackup.NewSnapshot()
+	err := req.Get(existing, backup.Status.Namespace, volumebackup.SnapshotName(backup))
+	if apierrors.IsNotFound(err) {
+		cfg, err := config.Get(req.Ctx, req.Client)
+		if err != nil {
+			return err
+		}
+		if cfg.VolumeSnapshotClass == nil || *cfg.VolumeSnapshotClass == "" {
+			return fmt.Errorf("volume snapshots are not configured, set --volume-snapshot-class with acorn install")
+		}
+		resp.Objects(volumebackup.Snapshot(backup, *cfg.VolumeSnapshotClass))
+		cond.Unknown("waiting for snapshot")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// The spec of a snapshot can not be changed, so the class it was created with is kept
+	class, _, _ := unstructured.NestedString(existing.Object, "spec", "volumeSnapshotClassName")
+	resp.Objects(volumebackup.Snapshot(backup, class))
+
+	ready, _, errMessage := volumebackup.SnapshotStatus(existing)
+	switch {
+	case errMessage != "":
+		backup.Status.Ready = false
+		cond.Error(fmt.Errorf("snapshot failed: %s", errMessage))
+	case ready:
+		backup.Status.Ready = true
+		cond.Success()
+	default:
+		cond.Unknown("waiting for snapshot")
+	}
+	return nil
+}
+
+func upload(req router.Request, resp router.Response, backup *v1.VolumeBackupInstance, cond *condition.Callback) error {
+	if backup.Status.Ready {
+		cond.Success()
+		return nil
+	}
+
+	var nodeName string
+	job := &batchv1.Job{}
+	if err := req.Get(job, backup.Status.Namespace, volumebackup.BackupJobName(backup)); err == nil {
+		if finished, failure := volumebackup.IsJobFinished(job); finished && failure == "" {
+			backup.Status.Ready = true
+			cond.Success()
+			return nil
+		} else if finished {
+			cond.Error(fmt.Errorf("backup failed: %s", failure))
+		} else {
+			cond.Unknown("waiting for backup to be uploaded")
+		}
+		// The pod template of a Job can not be changed
+		nodeName = job.Spec.Template.Spec.NodeName
+	} else if apierrors.IsNotFound(err) {
+		cond.Unknown("waiting for backup to be uploaded")
+		// A ReadWriteOnce volume that is in use can only be mounted on the node of the pod using it
+		nodeName, err = volumebackup.ClaimNodeName(req.Ctx, req.Client, backup.Status.Namespace, backup.Status.ClaimName)
+		if err != nil {
+			return err
+		}
+	} else {
+		return err
+	}
+
+	target, err := volumebackup.GetTarget(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	key := target.Key(backup.Namespace, backup.Name)
+	if backup.Status.UploadID == "" {
+		uploadID, err := target.CreateMultipartUpload(req.Ctx, key)
+		if err != nil {
+			return err
+		}
+		backup.Status.UploadID = uploadID
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	if err := req.Get(claim, backup.Status.Namespace, backup.Status.ClaimName); err != nil {
+		return err
+	}
+	capacity := claim.Status.Capacity[corev1.ResourceStorage]
+	// The archive can be larger than the data because of the tar headers and files that do not compress
+	maxSize := capacity.Value() + capacity.Value()/10
+
+	// The URLs are signed from the creation time so that the Secret does not change
+	multipartUpload, err := target.MultipartUpload(key, backup.Status.UploadID, maxSize, backup.CreationTimestamp.Time)
+	if err != nil {
+		return err
+	}
+	secret, err := volumebackup.BackupSecret(backup, multipartUpload)
+	if err != nil {
+		return err
+	}
+
+	resp.Objects(secret, volumebackup.BackupJob(backup, nodeName))
+	return nil
+}
+
+// DeleteBackup removes the archive of the backup from S3. A snapshot is removed with the backup like any other
+// object it created.
+func DeleteBackup(req router.Request, resp router.Response) error {
+	backup := req.Object.(*v1.VolumeBackupInstance)
+	if backup.Status.Method != v1.VolumeBackupMethodS3 {
+		return nil
+	}
+
+	target, err := volumebackup.GetTarget(req.Ctx, req.Client)
+	if err != nil {
+		// Without a target there is nothing that can be done, the backup must not get stuck on deletion
+		logrus.Warnf("not removing archive of volume backup %s/%s: %v", backup.Namespace, backup.Name, err)
+		return nil
+	}
+
+	key := target.Key(backup.Namespace, backup.Name)
+	if !backup.Status.Ready && backup.Status.UploadID != "" {
+		if err := target.AbortMultipartUpload(req.Ctx, key, backup.Status.UploadID); err != nil {
+			return err
+		}
+	}
+	return target.Delete(req.Ctx, key)
+}
@@ -0,0 +1,208 @@
+package volumebackup
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func existing(backupURL string) []kclient.Object {
+	return []kclient.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.ConfigName,
+				Namespace: system.Namespace,
+			},
+			Data: map[string]string{
+				"config": `{"volumeBackupURL": "` + backupURL + `"}`,
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.VolumeBackupSecretName,
+				Namespace: system.Namespace,
+			},
+			Data: map[string][]byte{
+				"accessKeyID":     []byte("access"),
+				"secretAccessKey": []byte("secret"),
+			},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pvc-1234",
+				Labels: map[string]string{
+					labels.AcornAppName:      "app-name",
+					labels.AcornAppNamespace: "app-ns",
+					labels.AcornVolumeName:   "data",
+				},
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				ClaimRef: &corev1.ObjectReference{
+					Namespace: "app-target-ns",
+					Name:      "data",
+				},
+			},
+			Status: corev1.PersistentVolumeStatus{
+				Phase: corev1.VolumeBound,
+			},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "data",
+				Namespace: "app-target-ns",
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10G"),
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "app-pod",
+				Namespace: "app-target-ns",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+				Volumes: []corev1.Volume{
+					{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "data",
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		},
+	}
+}
+
+// s3Server answers the requests that start a multipart upload
+func s3Server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Query().Has("uploads") {
+			_, _ = w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestBackupUpload(t *testing.T) {
+	backup := &v1.VolumeBackupInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name-volume-abcde",
+			Namespace: "app-ns",
+		},
+		Spec: v1.VolumeBackupInstanceSpec{
+			Volume: "pvc-1234",
+		},
+	}
+
+	server := s3Server()
+	defer server.Close()
+
+	h := tester.Harness{
+		Scheme:   scheme.Scheme,
+		Existing: existing(server.URL + "/backups"),
+	}
+	resp, err := h.InvokeFunc(t, backup, BackupVolume)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := backup.Status
+	assert.Equal(t, v1.VolumeBackupMethodS3, status.Method)
+	assert.Equal(t, "app-name", status.AppName)
+	assert.Equal(t, "data", status.VolumeName)
+	assert.Equal(t, "app-target-ns", status.Namespace)
+	assert.Equal(t, "data", status.ClaimName)
+	assert.Equal(t, server.URL+"/backups/app-ns/app-name-volume-abcde.tar.gz", status.Location)
+	assert.Equal(t, "upload-1", status.UploadID)
+	assert.False(t, status.Ready)
+
+	assert.Len(t, resp.Collected, 3)
+	secret := resp.Collected[1].(*corev1.Secret)
+	assert.Equal(t, "acorn-backup-app-name-volume-abcde", secret.Name)
+	assert.Equal(t, "app-target-ns", secret.Namespace)
+
+	upload := &volumebackup.MultipartUpload{}
+	if err := json.Unmarshal(secret.Data["upload.json"], upload); err != nil {
+		t.Fatal(err)
+	}
+	// The parts cover 10% more than the capacity of the volume
+	assert.Len(t, upload.Parts, 656)
+	assert.Contains(t, upload.Complete, "uploadId=upload-1")
+
+	job := resp.Collected[2].(*batchv1.Job)
+	assert.Equal(t, "acorn-backup-app-name-volume-abcde", job.Name)
+	assert.Equal(t, "app-target-ns", job.Namespace)
+	assert.Equal(t, "app-name", job.Spec.Template.Labels[labels.AcornAppName])
+	assert.Equal(t, "node-1", job.Spec.Template.Spec.NodeName)
+	assert.Equal(t, []string{"volume-helper", "upload", "/upload/upload.json", "/data"}, job.Spec.Template.Spec.Containers[0].Args)
+	assert.Equal(t, "data", job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.True(t, job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ReadOnly)
+	assert.Equal(t, secret.Name, job.Spec.Template.Spec.Volumes[2].Secret.SecretName)
+
+	// Once the Job completed the backup is ready and the Job is no longer needed
+	job.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:   batchv1.JobComplete,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	h.Existing = append(existing(server.URL+"/backups"), job)
+	resp, err = h.InvokeFunc(t, backup, BackupVolume)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, backup.Status.Ready)
+	assert.Len(t, resp.Collected, 0)
+}
+
+func TestBackupNotConfigured(t *testing.T) {
+	backup := &v1.VolumeBackupInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name-volume-abcde",
+			Namespace: "app-ns",
+		},
+		Spec: v1.VolumeBackupInstanceSpec{
+			Volume: "pvc-1234",
+		},
+	}
+
+	h := tester.Harness{
+		Scheme:   scheme.Scheme,
+		Existing: existing("")[2:],
+	}
+	resp, err := h.InvokeFunc(t, backup, BackupVolume)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, resp.Collected, 1)
+	assert.Equal(t, "", backup.Status.Method)
+	assert.Len(t, backup.Status.Conditions, 1)
+	assert.Equal(t, v1.VolumeBackupInstanceConditionBackup, backup.Status.Conditions[0].Type)
+	assert.Contains(t, backup.Status.Conditions[0].Message, "volume backups are not configured")
+}
@@ -0,0 +1,189 @@
+package cp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/client/term"
+)
+
+const (
+	// The size is written to stderr before the archive so that progress can be shown for downloads
+	downloadScript = `du -sk "$0/$1" >&2 2>/dev/null || echo 0 >&2; exec tar -cf - -C "$0" "$1"`
+	uploadScript   = `mkdir -p "$0" && exec tar -xmf - -C "$0"`
+)
+
+// Copy copies src to dst. One of them must be a local path and the other a path in a container or volume, see
+// ParseLocation. The copied bytes are reported to progress, the channel is not closed.
+func Copy(ctx context.Context, c client.Client, src, dst string, progress chan<- client.ImageProgress) error {
+	srcLocation, err := ParseLocation(src)
+	if err != nil {
+		return err
+	}
+	dstLocation, err := ParseLocation(dst)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case srcLocation != nil && dstLocation != nil:
+		return fmt.Errorf("copying between [%s] and [%s] is not supported, one must be a local path", src, dst)
+	case srcLocation == nil && dstLocation == nil:
+		return fmt.Errorf("one of [%s] and [%s] must be in the form CONTAINER_NAME:PATH or volume:VOLUME_NAME:PATH", src, dst)
+	case dstLocation != nil:
+		return upload(ctx, c, src, dstLocation, progress)
+	default:
+		return download(ctx, c, srcLocation, dst, progress)
+	}
+}
+
+// upload copies the local path src to dst. A trailing slash on dst copies src into that directory, otherwise src is
+// copied to dst itself.
+func upload(ctx context.Context, c client.Client, src string, dst *Location, progress chan<- client.ImageProgress) error {
+	total, err := size(src)
+	if err != nil {
+		return err
+	}
+
+	dir, name := path.Split(dst.fullPath())
+	if name == "" {
+		name = filepath.Base(src)
+	}
+
+	cIO, err := dst.exec(ctx, c, []string{"sh", "-c", uploadScript, dir})
+	if err != nil {
+		return err
+	}
+
+	var (
+		stderr = &bytes.Buffer{}
+		wg     sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(io.Discard, cIO.Stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(stderr, cIO.Stderr)
+	}()
+
+	counter := newCounter(total, progress)
+	err = writeArchive(cIO.Stdin, src, name, counter.add)
+	_ = cIO.Stdin.Close()
+	wg.Wait()
+
+	return result(dst, cIO, stderr, err)
+}
+
+// download copies src to the local path dst. If dst is an existing directory src is copied into it, otherwise src is
+// copied to dst itself.
+func download(ctx context.Context, c client.Client, src *Location, dst string, progress chan<- client.ImageProgress) error {
+	full := src.fullPath()
+	if full != "/" {
+		full = strings.TrimSuffix(full, "/")
+	}
+	dir, name := path.Dir(full), path.Base(full)
+	if full == "/" {
+		name = "."
+	}
+
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		dst = filepath.Join(dst, name)
+	}
+
+	cIO, err := src.exec(ctx, c, []string{"sh", "-c", downloadScript, dir, name})
+	if err != nil {
+		return err
+	}
+	defer cIO.Stdin.Close()
+
+	// The first line of stderr is the output of du in KiB
+	stderr := bufio.NewReader(cIO.Stderr)
+	line, err := stderr.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	var kib int64
+	if fields := strings.Fields(line); len(fields) > 0 {
+		kib, _ = strconv.ParseInt(fields[0], 10, 64)
+	}
+
+	errOutput := &bytes.Buffer{}
+	errDone := make(chan struct{})
+	go func() {
+		defer close(errDone)
+		_, _ = io.Copy(errOutput, stderr)
+	}()
+
+	top := name
+	if top == "." {
+		top = ""
+	}
+	counter := newCounter(kib*1024, progress)
+	err = extractArchive(cIO.Stdout, dst, top, counter.add)
+	_, _ = io.Copy(io.Discard, cIO.Stdout)
+	<-errDone
+
+	return result(src, cIO, errOutput, err)
+}
+
+// result returns the error of the command if it failed, before the error of the local side of the copy, because
+// that is usually only caused by the command failing
+func result(location *Location, cIO *term.ExecIO, stderr *bytes.Buffer, err error) error {
+	exit := <-cIO.ExitCode
+	if exit.Err != nil {
+		return fmt.Errorf("copying %s: %w", location, exit.Err)
+	}
+	if exit.Code != 0 {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = fmt.Sprintf("exit code %d", exit.Code)
+		}
+		return fmt.Errorf("copying %s: %s", location, msg)
+	}
+	if err != nil {
+		return fmt.Errorf("copying %s: %w", location, err)
+	}
+	return nil
+}
+
+type counter struct {
+	total    int64
+	complete int64
+	progress chan<- client.ImageProgress
+}
+
+func newCounter(total int64, progress chan<- client.ImageProgress) *counter {
+	return &counter{
+		total:    total,
+		progress: progress,
+	}
+}
+
+func (c *counter) add(n int64) {
+	c.complete += n
+	if c.progress == nil {
+		return
+	}
+	// The size of a download is only an estimate, so complete is kept below the total
+	complete := c.complete
+	if complete > c.total {
+		complete = c.total
+	}
+	c.progress <- client.ImageProgress{
+		Total:    c.total,
+		Complete: complete,
+	}
+}
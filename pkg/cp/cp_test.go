@@ -0,0 +1,122 @@
+package cp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/client/term"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localClient runs the commands of a copy on the local machine, so the container paths are local paths
+type localClient struct {
+	client.Client
+}
+
+func (l *localClient) ContainerReplicaList(ctx context.Context, opts *client.ContainerReplicaListOptions) ([]apiv1.ContainerReplica, error) {
+	return nil, nil
+}
+
+func (l *localClient) ContainerReplicaExec(ctx context.Context, name string, args []string, tty bool, opts *client.ContainerReplicaExecOptions) (*term.ExecIO, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stderrR, stderrW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout, cmd.Stderr = stdoutW, stderrW
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	exit := make(chan term.ExitCode, 1)
+	go func() {
+		err := cmd.Wait()
+		_ = stdoutW.Close()
+		_ = stderrW.Close()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exit <- term.ExitCode{Code: exitErr.ExitCode()}
+		} else {
+			exit <- term.ExitCode{Err: err}
+		}
+	}()
+
+	return &term.ExecIO{
+		Stdin:    stdin,
+		Stdout:   stdoutR,
+		Stderr:   stderrR,
+		ExitCode: exit,
+	}, nil
+}
+
+func TestCopy(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		c         = &localClient{}
+		local     = t.TempDir()
+		container = t.TempDir()
+		back      = t.TempDir()
+	)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "dir", "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "dir", "sub", "file"), []byte("content"), 0600))
+	require.NoError(t, os.Symlink("sub/file", filepath.Join(local, "dir", "link")))
+
+	// Upload to a new name
+	require.NoError(t, Copy(ctx, c, filepath.Join(local, "dir"), "app.web:"+container+"/copy", nil))
+	data, err := os.ReadFile(filepath.Join(container, "copy", "sub", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	// Upload into a directory
+	require.NoError(t, Copy(ctx, c, filepath.Join(local, "dir", "sub", "file"), "app.web:"+container+"/into/", nil))
+	data, err = os.ReadFile(filepath.Join(container, "into", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	// Download into an existing directory
+	progress := make(chan client.ImageProgress, 100)
+	require.NoError(t, Copy(ctx, c, "app.web:"+container+"/copy", back, progress))
+	close(progress)
+	data, err = os.ReadFile(filepath.Join(back, "copy", "sub", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+	link, err := os.Readlink(filepath.Join(back, "copy", "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "sub/file", link)
+
+	var last client.ImageProgress
+	for update := range progress {
+		last = update
+	}
+	// The total is estimated with du, so it only has to be at least the copied content
+	assert.Equal(t, int64(len("content")), last.Complete)
+	assert.GreaterOrEqual(t, last.Total, last.Complete)
+
+	// Download a file to a new name
+	require.NoError(t, Copy(ctx, c, "app.web:"+container+"/into/file", filepath.Join(back, "renamed"), nil))
+	data, err = os.ReadFile(filepath.Join(back, "renamed"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	err = Copy(ctx, c, "app.web:"+container+"/missing", back, nil)
+	assert.ErrorContains(t, err, "copying app.web:"+container+"/missing")
+
+	assert.Error(t, Copy(ctx, c, local, back, nil))
+	assert.Error(t, Copy(ctx, c, "app.web:/a", "app.db:/b", nil))
+}
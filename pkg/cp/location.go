@@ -0,0 +1,140 @@
+package cp
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/client/term"
+	"github.com/acorn-io/acorn/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const volumePrefix = "volume:"
+
+// Location is a path in a container or a volume
+type Location struct {
+	// Container is a container replica name or APP_NAME.CONTAINER_NAME
+	Container string
+	Volume    string
+	Path      string
+}
+
+func (l *Location) String() string {
+	if l.Volume != "" {
+		return volumePrefix + l.Volume + ":" + l.Path
+	}
+	return l.Container + ":" + l.Path
+}
+
+// ParseLocation parses CONTAINER:PATH and volume:VOLUME_NAME:PATH. Nil is returned for a local path, which is any
+// argument without a colon or that starts like a path.
+func ParseLocation(arg string) (*Location, error) {
+	if strings.HasPrefix(arg, volumePrefix) {
+		volume, p, ok := strings.Cut(strings.TrimPrefix(arg, volumePrefix), ":")
+		if !ok || volume == "" {
+			return nil, fmt.Errorf("invalid volume path [%s], must be in the form volume:VOLUME_NAME:PATH", arg)
+		}
+		return &Location{
+			Volume: volume,
+			Path:   remotePath(p),
+		}, nil
+	}
+
+	container, p, ok := strings.Cut(arg, ":")
+	if !ok || isLocal(container) {
+		return nil, nil
+	}
+	if container == "" {
+		return nil, fmt.Errorf("invalid container path [%s], must be in the form CONTAINER_NAME:PATH", arg)
+	}
+	return &Location{
+		Container: container,
+		Path:      remotePath(p),
+	}, nil
+}
+
+func isLocal(prefix string) bool {
+	return strings.HasPrefix(prefix, ".") ||
+		strings.HasPrefix(prefix, "/") ||
+		strings.HasPrefix(prefix, "~") ||
+		strings.Contains(prefix, `\`) ||
+		// A Windows drive letter
+		len(prefix) == 1
+}
+
+// remotePath makes a relative path absolute, keeping a trailing slash that asks to copy into a directory
+func remotePath(p string) string {
+	result := path.Clean("/" + p)
+	if strings.HasSuffix(p, "/") && result != "/" {
+		result += "/"
+	}
+	return result
+}
+
+// fullPath is the path in the container the command runs in
+func (l *Location) fullPath() string {
+	if l.Volume == "" {
+		return l.Path
+	}
+	result := path.Join(system.VolumeExecMountPath, l.Path)
+	if strings.HasSuffix(l.Path, "/") {
+		result += "/"
+	}
+	return result
+}
+
+func (l *Location) exec(ctx context.Context, c client.Client, command []string) (*term.ExecIO, error) {
+	if l.Volume != "" {
+		// A volume of another project is passed as PROJECT/VOLUME_NAME and the project is kept on the name so
+		// that the request is sent to the right project
+		name, volume := "_", l.Volume
+		if i := strings.LastIndex(volume, "/"); i != -1 {
+			name, volume = volume[:i+1]+name, volume[i+1:]
+		}
+		return c.ContainerReplicaExec(ctx, name, command, false, &client.ContainerReplicaExecOptions{
+			Volume: volume,
+		})
+	}
+
+	container, err := l.containerName(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return c.ContainerReplicaExec(ctx, container, command, false, nil)
+}
+
+// containerName resolves APP_NAME.CONTAINER_NAME to a running replica of the container. Anything else is used as the
+// name of a container replica.
+func (l *Location) containerName(ctx context.Context, c client.Client) (string, error) {
+	appName, containerName, ok := strings.Cut(l.Container, ".")
+	if !ok {
+		return l.Container, nil
+	}
+
+	replicas, err := c.ContainerReplicaList(ctx, &client.ContainerReplicaListOptions{
+		App: appName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, replica := range replicas {
+		if replica.Name == l.Container {
+			return replica.Name, nil
+		}
+	}
+	for _, replica := range replicas {
+		name := replica.Spec.ContainerName
+		if replica.Spec.SidecarName != "" {
+			name = replica.Spec.SidecarName
+		}
+		if name == containerName && replica.Status.Phase == corev1.PodRunning {
+			return replica.Name, nil
+		}
+	}
+
+	return l.Container, nil
+}
@@ -0,0 +1,45 @@
+package cp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected *Location
+		wantErr  bool
+	}{
+		{arg: "file.txt"},
+		{arg: "./dir:with:colons"},
+		{arg: "/tmp/a:b"},
+		{arg: `C:\data`},
+		{arg: "app.web:/tmp/file", expected: &Location{Container: "app.web", Path: "/tmp/file"}},
+		{arg: "app.web:tmp/dir/", expected: &Location{Container: "app.web", Path: "/tmp/dir/"}},
+		{arg: "app.web-abc-xyz:../../etc", expected: &Location{Container: "app.web-abc-xyz", Path: "/etc"}},
+		{arg: "volume:pvc-1234:/data", expected: &Location{Volume: "pvc-1234", Path: "/data"}},
+		{arg: "volume:pvc-1234:", expected: &Location{Volume: "pvc-1234", Path: "/"}},
+		{arg: "volume:pvc-1234", wantErr: true},
+		{arg: ":/tmp", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			location, err := ParseLocation(tt.arg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, location)
+		})
+	}
+}
+
+func TestFullPath(t *testing.T) {
+	assert.Equal(t, "/tmp/file", (&Location{Container: "app.web", Path: "/tmp/file"}).fullPath())
+	assert.Equal(t, "/volume/data", (&Location{Volume: "pvc-1234", Path: "/data"}).fullPath())
+	assert.Equal(t, "/volume/data/", (&Location{Volume: "pvc-1234", Path: "/data/"}).fullPath())
+	assert.Equal(t, "/volume/", (&Location{Volume: "pvc-1234", Path: "/"}).fullPath())
+}
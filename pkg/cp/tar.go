@@ -0,0 +1,201 @@
+package cp
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// size returns the number of bytes in the regular files of src
+func size(src string) (int64, error) {
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// writeArchive writes src as a tar to w with name as the top level entry. A symlink given as src is followed,
+// symlinks inside a directory are kept. Written file content is reported to count.
+func writeArchive(w io.Writer, src, name string, count func(int64)) error {
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var link string
+		switch {
+		case info.Mode().IsRegular(), info.IsDir():
+		case info.Mode()&os.ModeSymlink != 0:
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, &countingReader{r: f, count: count})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractArchive reads a tar from r and writes the entry top to dst, if top is empty all entries are written to dst.
+// Entries can not be written outside of dst and symlinks are created last so that no entry is written through a
+// symlink of the archive. Written file content is reported to count.
+func extractArchive(r io.Reader, dst, top string, count func(int64)) error {
+	var (
+		dirs     []*tar.Header
+		symlinks []*tar.Header
+		tr       = tar.NewReader(r)
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		target, ok := extractPath(dst, top, hdr.Name)
+		if !ok {
+			continue
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			dirs = append(dirs, hdr)
+		case tar.TypeReg:
+			if err := extractFile(&countingReader{r: tr, count: count}, hdr, target); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			symlinks = append(symlinks, hdr)
+		}
+	}
+
+	for _, hdr := range symlinks {
+		target, _ := extractPath(dst, top, hdr.Name)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		if err := os.Symlink(hdr.Linkname, target); err != nil {
+			return err
+		}
+	}
+
+	// Directories get their mode last so that a read only directory could still be filled
+	for i := len(dirs) - 1; i >= 0; i-- {
+		target, _ := extractPath(dst, top, dirs[i].Name)
+		_ = os.Chmod(target, dirs[i].FileInfo().Mode().Perm())
+	}
+
+	return nil
+}
+
+// extractPath replaces top in name with dst and returns false if name is not top or below it. Cleaning the name as
+// an absolute path keeps it inside dst.
+func extractPath(dst, top, name string) (string, bool) {
+	rel := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if top != "" {
+		if rel != top && !strings.HasPrefix(rel, top+"/") {
+			return "", false
+		}
+		rel = strings.TrimPrefix(strings.TrimPrefix(rel, top), "/")
+	}
+	return filepath.Join(dst, filepath.FromSlash(rel)), true
+}
+
+func extractFile(r io.Reader, hdr *tar.Header, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, hdr.FileInfo().Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", target, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+}
+
+type countingReader struct {
+	r     io.Reader
+	count func(int64)
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 && c.count != nil {
+		c.count(int64(n))
+	}
+	return n, err
+}
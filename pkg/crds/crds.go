@@ -10,6 +10,10 @@ import (
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+type namespaceScoped interface {
+	NamespaceScoped() bool
+}
+
 func Create(ctx context.Context, scheme *runtime.Scheme, gvs ...schema.GroupVersion) error {
 	var wranglerCRDs []crd.CRD
 
@@ -23,10 +27,12 @@ func Create(ctx context.Context, scheme *runtime.Scheme, gvs ...schema.GroupVers
 			_, isObj := obj.(kclient.Object)
 			_, isListObj := obj.(kclient.ObjectList)
 			if isObj && !isListObj {
+				scoped, ok := obj.(namespaceScoped)
 				wranglerCRDs = append(wranglerCRDs, crd.CRD{
 					GVK:          gvk,
 					SchemaObject: obj,
 					Status:       true,
+					NonNamespace: ok && !scoped.NamespaceScoped(),
 				}.WithColumnsFromStruct(obj))
 			}
 		}
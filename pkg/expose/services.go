@@ -0,0 +1,131 @@
+package expose
+
+import (
+	"fmt"
+	"net"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/ports"
+	"github.com/acorn-io/baaah/pkg/typed"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExternalServices creates a service in the app namespace for each service declared in the services section of the
+// app. Hostnames become ExternalName services and IP addresses become services without a selector and a matching
+// Endpoints object.
+func ExternalServices(app *v1.AppInstance) (result []kclient.Object, _ error) {
+	for _, entry := range typed.Sorted(app.Status.AppSpec.Services) {
+		serviceName, service := entry.Key, entry.Value
+		if ports.IsLinked(app, serviceName) {
+			continue
+		}
+
+		address, servicePorts, err := resolveExternalService(app, serviceName, service)
+		if err != nil {
+			return nil, err
+		}
+
+		objs, err := toExternalService(app, serviceName, address, servicePorts)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, objs...)
+	}
+
+	return
+}
+
+// resolveExternalService applies the binding for the service passed when the app was run, if there is one
+func resolveExternalService(app *v1.AppInstance, serviceName string, service v1.Service) (string, []v1.PortDef, error) {
+	var (
+		address      = service.Address
+		servicePorts []v1.PortDef
+	)
+
+	for _, port := range service.Ports {
+		servicePorts = append(servicePorts, port.Complete(serviceName))
+	}
+
+	for _, binding := range app.Spec.ExternalServices {
+		if binding.Target != serviceName {
+			continue
+		}
+		address = binding.Address
+		if binding.Port == 0 {
+			break
+		}
+		switch len(servicePorts) {
+		case 0:
+			servicePorts = append(servicePorts, v1.PortDef{
+				Port:       binding.Port,
+				TargetPort: binding.Port,
+			}.Complete(serviceName))
+		case 1:
+			servicePorts[0].TargetPort = binding.Port
+		default:
+			return "", nil, fmt.Errorf("service [%s] has more than one port, a port can not be set when binding it", serviceName)
+		}
+	}
+
+	if address == "" {
+		return "", nil, fmt.Errorf("service [%s] has no address, one must be bound when running the app", serviceName)
+	}
+
+	return address, servicePorts, nil
+}
+
+func toExternalService(app *v1.AppInstance, serviceName, address string, servicePorts []v1.PortDef) ([]kclient.Object, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: app.Status.Namespace,
+			Labels:    labels.Managed(app, labels.AcornServiceName, serviceName),
+		},
+	}
+	for _, port := range servicePorts {
+		service.Spec.Ports = append(service.Spec.Ports, ports.ToServicePort(port))
+	}
+
+	if net.ParseIP(address) == nil {
+		// A hostname is resolved by DNS so the port that is used can not be changed
+		for _, port := range servicePorts {
+			if port.Port != port.TargetPort {
+				return nil, fmt.Errorf("service [%s] with hostname [%s] can not map port %d to %d, an IP address must be used", serviceName, address, port.Port, port.TargetPort)
+			}
+		}
+		service.Spec.Type = corev1.ServiceTypeExternalName
+		service.Spec.ExternalName = address
+		return []kclient.Object{service}, nil
+	}
+
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service.Name,
+			Namespace: service.Namespace,
+			Labels:    service.Labels,
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP: address,
+					},
+				},
+			},
+		},
+	}
+	for _, port := range service.Spec.Ports {
+		endpoints.Subsets[0].Ports = append(endpoints.Subsets[0].Ports, corev1.EndpointPort{
+			Name:        port.Name,
+			Port:        port.TargetPort.IntVal,
+			Protocol:    port.Protocol,
+			AppProtocol: port.AppProtocol,
+		})
+	}
+
+	return []kclient.Object{service, endpoints}, nil
+}
@@ -20,11 +20,20 @@ const (
 	AcornDepNames                = Prefix + "dep-names"
 	AcornAppUID                  = Prefix + "app-uid"
 	AcornVolumeName              = Prefix + "volume-name"
+	AcornVolumeBackupName        = Prefix + "volume-backup-name"
+	AcornVolumeRestore           = Prefix + "volume-restore"
+	AcornVolumeSeed              = Prefix + "volume-seed"
+	AcornVolumeSeeded            = Prefix + "volume-seeded"
 	AcornSecretName              = Prefix + "secret-name"
 	AcornSecretGenerated         = Prefix + "secret-generated"
+	AcornSecretRotations         = Prefix + "secret-rotations"
+	AcornSecretProvider          = Prefix + "secret-provider"
 	AcornContainerName           = Prefix + "container-name"
+	AcornContainerReplica        = Prefix + "container-replica"
 	AcornRouterName              = Prefix + "router-name"
 	AcornJobName                 = Prefix + "job-name"
+	AcornJobEvent                = Prefix + "job-event"
+	AcornJobRun                  = Prefix + "job-run"
 	AcornAppImage                = Prefix + "app-image"
 	AcornAppCuePath              = Prefix + "app-cue-path"
 	AcornAppCuePathHash          = Prefix + "app-cue-path-hash"
@@ -37,6 +46,7 @@ const (
 	AcornSecretRevPrefix         = "secret-rev." + Prefix
 	AcornPublishURL              = Prefix + "publish-url"
 	AcornTargets                 = Prefix + "targets"
+	AcornTLSHosts                = Prefix + "tls-hosts"
 	AcornDNSHash                 = Prefix + "dns-hash"
 	AcornLinkName                = Prefix + "link-name"
 	AcornDNSState                = Prefix + "applied-dns-state"
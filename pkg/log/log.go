@@ -16,6 +16,7 @@ import (
 	"github.com/acorn-io/baaah/pkg/restconfig"
 	"github.com/acorn-io/baaah/pkg/watcher"
 	"golang.org/x/sync/errgroup"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,6 +62,7 @@ type Message struct {
 	Pod           *corev1.Pod
 	ContainerName string
 	Time          time.Time
+	JobRunResult  batchv1.JobConditionType
 
 	Err error
 }
@@ -72,6 +74,7 @@ type Options struct {
 	Tail             *int64
 	Follow           bool
 	ContainerReplica string
+	JobRun           string
 }
 
 func (o *Options) restConfig() (*rest.Config, error) {
@@ -286,7 +289,14 @@ func Pod(ctx context.Context, pod *corev1.Pod, output chan<- Message, options *O
 	eg, _ := errgroup.WithContext(ctx)
 
 	eg.Go(func() error {
-		defer cancel()
+		var jobRunFinished bool
+		defer func() {
+			// The containers of a finished job run pod are not restarted, their streams are left to end on their own so
+			// that the logs are read to the end
+			if !jobRunFinished {
+				cancel()
+			}
+		}()
 		_, err = podWatcher.ByName(ctx, pod.Namespace, pod.Name, func(pod *corev1.Pod) (bool, error) {
 			if !pod.DeletionTimestamp.IsZero() {
 				return true, nil
@@ -337,7 +347,9 @@ func Pod(ctx context.Context, pod *corev1.Pod, output chan<- Message, options *O
 					})
 				}
 			}
-			return false, nil
+			jobRunFinished = options.JobRun != "" &&
+				(pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed)
+			return jobRunFinished, nil
 		})
 		return err
 	})
@@ -376,13 +388,28 @@ func appNoFollow(ctx context.Context, app *apiv1.App, output chan<- Message, opt
 }
 
 func matchesPod(pod *corev1.Pod, options *Options) bool {
-	if options == nil || options.ContainerReplica == "" {
+	if options == nil {
+		return true
+	}
+	if options.JobRun != "" && !isJobRunPod(pod, options.JobRun) {
+		return false
+	}
+	if options.ContainerReplica == "" {
 		return true
 	}
 	parts := strings.SplitN(options.ContainerReplica, ".", 3)
 	return len(parts) > 1 && pod.Name == parts[1]
 }
 
+func isJobRunPod(pod *corev1.Pod, jobRun string) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "Job" && owner.Name == jobRun {
+			return true
+		}
+	}
+	return false
+}
+
 func matchesContainer(pod *corev1.Pod, container corev1.Container, options *Options) bool {
 	if options == nil || options.ContainerReplica == "" {
 		return true
@@ -410,6 +437,8 @@ func App(ctx context.Context, app *apiv1.App, output chan<- Message, options *Op
 		appWatcher = watcher.New[*apiv1.App](options.Client)
 		podWatcher = watcher.New[*corev1.Pod](options.Client)
 		watching   = watching{}
+		podLogs    sync.WaitGroup
+		jobRunErr  error
 	)
 
 	app, err = appWatcher.ByName(ctx, app.Namespace, app.Name, func(app *apiv1.App) (bool, error) {
@@ -422,6 +451,13 @@ func App(ctx context.Context, app *apiv1.App, output chan<- Message, options *Op
 	podSelector := labels.SelectorFromSet(labels.Set{
 		applabels.AcornManaged: "true",
 	})
+	if options.JobRun != "" {
+		// The pods of a job run are not managed by the app, matchesPod picks the pods of the run
+		podSelector = labels.SelectorFromSet(labels.Set{
+			applabels.AcornAppName:      app.Name,
+			applabels.AcornAppNamespace: app.Namespace,
+		})
+	}
 
 	// Ensure that if once func finishes they are all canceled
 	ctx, cancel := context.WithCancel(ctx)
@@ -434,7 +470,9 @@ func App(ctx context.Context, app *apiv1.App, output chan<- Message, options *Op
 				return false, nil
 			}
 			if watching.shouldWatch("Pod", pod.Namespace, pod.Name) {
+				podLogs.Add(1)
 				eg.Go(func() error {
+					defer podLogs.Done()
 					err := Pod(ctx, pod, output, options)
 					if err != nil {
 						output <- Message{
@@ -457,11 +495,69 @@ func App(ctx context.Context, app *apiv1.App, output chan<- Message, options *Op
 		})
 		return err
 	})
+	if options.JobRun != "" {
+		eg.Go(func() error {
+			defer cancel()
+			job, err := waitForJobRun(ctx, options.Client, app.Status.Namespace, options.JobRun, &podLogs)
+			if err != nil {
+				jobRunErr = err
+				return nil
+			}
+			output <- jobRunResult(job)
+			return nil
+		})
+	}
 
 	err = eg.Wait()
+	if jobRunErr != nil && !errors.Is(jobRunErr, context.Canceled) {
+		return jobRunErr
+	}
 	if errors.Is(err, context.Canceled) {
 		return nil
 	}
 
 	return err
 }
+
+// waitForJobRun waits for the job run to finish and for the logs of its pods to be read to the end
+func waitForJobRun(ctx context.Context, c client.WithWatch, namespace, name string, podLogs *sync.WaitGroup) (*batchv1.Job, error) {
+	job, err := watcher.New[*batchv1.Job](c).ByName(ctx, namespace, name, func(job *batchv1.Job) (bool, error) {
+		return jobRunCondition(job) != nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	podLogs.Wait()
+	return job, nil
+}
+
+// jobRunResult returns the message that reports the result of a finished job run from the conditions of the Job
+func jobRunResult(job *batchv1.Job) Message {
+	cond := jobRunCondition(job)
+	result := Message{
+		Time:         time.Now(),
+		JobRunResult: cond.Type,
+	}
+	if cond.Type == batchv1.JobFailed {
+		reason := cond.Message
+		if reason == "" {
+			reason = cond.Reason
+		}
+		result.Err = fmt.Errorf("job [%s] failed: %s", job.Name, reason)
+	}
+	return result
+}
+
+// jobRunCondition returns the Complete or Failed condition of the job, or nil if the job has not finished
+func jobRunCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		if cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
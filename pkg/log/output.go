@@ -2,10 +2,13 @@ package log
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	v1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/client"
 	"github.com/pterm/pterm"
 	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
 	"strings"
 	"time"
 )
@@ -34,12 +37,79 @@ func Output(ctx context.Context, c client.Client, name string, opts *client.LogO
 		return err
 	}
 
-	containerColors := map[string]pterm.Color{}
+	lastErr, err := output(msgs, opts.Since)
+	if err != nil {
+		return err
+	}
+	if lastErr != "" {
+		logrus.Error(lastErr)
+	}
+	return nil
+}
+
+// JobRunOutput prints the logs of a job run until it finishes. An error is returned if the Job of the run failed.
+func JobRunOutput(ctx context.Context, c client.Client, appName, jobRun string) error {
+	msgs, err := c.AppLog(ctx, appName, &client.LogOptions{
+		Follow: true,
+		JobRun: jobRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The result of the run is taken from the conditions of the Job and sent after the logs of its pods
+	var (
+		result v1.LogMessage
+		logs   = make(chan v1.LogMessage)
+	)
+	go func() {
+		defer close(logs)
+		for msg := range msgs {
+			if msg.JobRunResult != "" {
+				result = msg
+				continue
+			}
+			logs <- msg
+		}
+	}()
+
+	lastErr, err := output(logs, "")
+	if err != nil {
+		return err
+	}
+	if lastErr != "" {
+		logrus.Error(lastErr)
+	}
+
+	switch batchv1.JobConditionType(result.JobRunResult) {
+	case batchv1.JobComplete:
+		return nil
+	case batchv1.JobFailed:
+		return errors.New(result.Error)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("logs of job [%s] ended before it finished", jobRun)
+}
+
+// output prints the messages until the channel is closed. If the last message is an error it is returned instead
+// of printed.
+func output(msgs <-chan v1.LogMessage, since string) (string, error) {
+	var (
+		containerColors = map[string]pterm.Color{}
+		lastErr         string
+	)
 
 	for msg := range msgs {
-		result, err := SinceLogCheck(opts.Since, msg)
+		if lastErr != "" {
+			logrus.Error(lastErr)
+			lastErr = ""
+		}
+
+		result, err := SinceLogCheck(since, msg)
 		if err != nil {
-			return err
+			return "", err
 		}
 		if result {
 			if msg.Error == "" {
@@ -51,12 +121,12 @@ func Output(ctx context.Context, c client.Client, name string, opts *client.LogO
 
 				pterm.Printf("%s: %s\n", color.Sprint(msg.ContainerName), msg.Line)
 			} else if !strings.Contains(msg.Error, "context canceled") {
-				logrus.Error(msg.Error)
+				lastErr = msg.Error
 			}
 		}
 	}
 
-	return nil
+	return lastErr, nil
 }
 
 func SinceLogCheck(since string, msg v1.LogMessage) (bool, error) {
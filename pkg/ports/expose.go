@@ -130,7 +130,7 @@ func NewForRouterPublish(app *v1.AppInstance) (*Set, error) {
 	bound := map[v1.PortDef]bool{}
 
 	for port := range ps.Ports {
-		if port.Protocol != v1.ProtocolHTTP || !ps.IsRouterService(port.ServiceName) {
+		if !port.Protocol.IsHTTP() || !ps.IsRouterService(port.ServiceName) {
 			continue
 		}
 
@@ -155,7 +155,7 @@ func NewForRouterPublish(app *v1.AppInstance) (*Set, error) {
 				continue
 			}
 
-			if port.Protocol != v1.ProtocolHTTP || !ps.IsRouterService(port.ServiceName) {
+			if !port.Protocol.IsHTTP() || !ps.IsRouterService(port.ServiceName) {
 				continue
 			}
 
@@ -183,7 +183,7 @@ func NewForIngressPublish(app *v1.AppInstance) (*Set, error) {
 	bound := map[v1.PortDef]bool{}
 
 	for port := range ps.Ports {
-		if port.Protocol != v1.ProtocolHTTP || !ps.IsContainerService(port.ServiceName) {
+		if !port.Protocol.IsHTTP() || !ps.IsContainerService(port.ServiceName) {
 			continue
 		}
 
@@ -208,7 +208,7 @@ func NewForIngressPublish(app *v1.AppInstance) (*Set, error) {
 				continue
 			}
 
-			if port.Protocol != v1.ProtocolHTTP || !ps.IsContainerService(port.ServiceName) {
+			if !port.Protocol.IsHTTP() || !ps.IsContainerService(port.ServiceName) {
 				continue
 			}
 
@@ -283,7 +283,7 @@ func NewForAcornExpose(app *v1.AppInstance) (*Set, error) {
 }
 
 func matches(binding v1.PortBinding, port v1.PortDef) bool {
-	if port.Protocol == v1.ProtocolHTTP {
+	if port.Protocol.IsHTTP() {
 		if binding.TargetPort != 0 && binding.TargetPort != port.Port {
 			return false
 		}
@@ -376,6 +376,19 @@ func New(app *v1.AppInstance) (*Set, error) {
 		result.AddPorts(Target{RouterName: routerName}, RouterPortDef)
 	}
 
+	for _, entry := range typed.Sorted(app.Status.AppSpec.Acorns) {
+		acornName, acorn := entry.Key, entry.Value
+		if IsLinked(app, acornName) {
+			continue
+		}
+		for _, binding := range acorn.Ports {
+			if !binding.Expose {
+				continue
+			}
+			result.AddPorts(Target{AcornName: acornName}, ToPortDef(binding.Complete(acornName), binding.Protocol))
+		}
+	}
+
 	return result, validate(result)
 }
 
@@ -15,6 +15,11 @@ import (
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	traefikServersScheme = "traefik.ingress.kubernetes.io/service.serversscheme"
+	nginxBackendProtocol = "nginx.ingress.kubernetes.io/backend-protocol"
+)
+
 var (
 	RouterPortDef = v1.PortDef{
 		Expose:     true,
@@ -74,7 +79,7 @@ func ToServicePort(port v1.PortDef) corev1.ServicePort {
 	case v1.ProtocolTCP:
 	case v1.ProtocolUDP:
 		servicePort.Protocol = corev1.ProtocolUDP
-	case v1.ProtocolHTTP:
+	case v1.ProtocolHTTP, v1.ProtocolHTTP2, v1.ProtocolGRPC:
 		str := strings.ToUpper(string(port.Protocol))
 		servicePort.AppProtocol = &str
 	}
@@ -82,13 +87,47 @@ func ToServicePort(port v1.PortDef) corev1.ServicePort {
 }
 
 func NormalizeProto(proto v1.Protocol) v1.Protocol {
-	switch proto {
-	case v1.ProtocolHTTP:
+	if proto.IsHTTP() {
 		return v1.ProtocolTCP
 	}
 	return proto
 }
 
+// BackendProtocol returns the protocol of the HTTP ports if they are all http2 or all grpc. Ingress controllers can
+// only be told to use HTTP/2 for all ports of a service.
+func BackendProtocol(ports []v1.PortDef) (result v1.Protocol) {
+	for _, port := range ports {
+		if !port.Protocol.IsHTTP() {
+			continue
+		}
+		if port.Protocol == v1.ProtocolHTTP || (result != "" && result != port.Protocol) {
+			return ""
+		}
+		result = port.Protocol
+	}
+	return result
+}
+
+// ToServiceAnnotations returns the annotations that make ingress controllers connect to the pods of the service
+// with HTTP/2 if its ports are http2 or grpc
+func ToServiceAnnotations(ports []v1.PortDef) map[string]string {
+	result := map[string]string{}
+	if BackendProtocol(ports) != "" {
+		result[traefikServersScheme] = "h2c"
+	}
+	return result
+}
+
+// ToIngressAnnotations returns the annotations that make ingress controllers use gRPC for the backends of the
+// ingress if its ports are grpc
+func ToIngressAnnotations(ports []v1.PortDef) map[string]string {
+	result := map[string]string{}
+	if BackendProtocol(ports) == v1.ProtocolGRPC {
+		result[nginxBackendProtocol] = "GRPC"
+	}
+	return result
+}
+
 func ToContainerServices(app *v1.AppInstance, publish bool, namespace string, portSet *Set) (result []kclient.Object) {
 	for _, serviceName := range portSet.ServiceNames() {
 		if !portSet.IsContainerService(serviceName) {
@@ -113,7 +152,7 @@ func ToContainerServices(app *v1.AppInstance, publish bool, namespace string, po
 		}
 
 		labelMap := labels.Managed(app, extraLabels...)
-		anns := make(map[string]string)
+		anns := ToServiceAnnotations(servicePorts)
 		// This is complicated, but we need to do this because a service can be selecting multiple containers, if both
 		// containers have a port with the same serviceName. So, this logic finds all the containers a service is selecting and
 		// gathers the labels/annotations from them.
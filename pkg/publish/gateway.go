@@ -0,0 +1,267 @@
+package publish
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/rancher/wrangler/pkg/name"
+	"golang.org/x/exp/slices"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const GatewayGroup = "gateway.networking.k8s.io"
+
+// The Gateway API objects are handled as unstructured objects so that the Gateway API CRDs are only required if a
+// gateway is configured
+var (
+	GatewayGVK = schema.GroupVersionKind{
+		Group:   GatewayGroup,
+		Version: "v1beta1",
+		Kind:    "Gateway",
+	}
+	HTTPRouteGVK = schema.GroupVersionKind{
+		Group:   GatewayGroup,
+		Version: "v1beta1",
+		Kind:    "HTTPRoute",
+	}
+)
+
+type parentReference struct {
+	Group       string `json:"group"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type httpPathMatch struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type httpRouteMatch struct {
+	Path httpPathMatch `json:"path"`
+}
+
+type httpBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+type httpRouteRule struct {
+	Matches     []httpRouteMatch `json:"matches"`
+	BackendRefs []httpBackendRef `json:"backendRefs"`
+}
+
+type httpRouteSpec struct {
+	ParentRefs []parentReference `json:"parentRefs"`
+	Hostnames  []string          `json:"hostnames"`
+	Rules      []httpRouteRule   `json:"rules"`
+}
+
+// GatewayRef returns the namespace and name of the configured gateway. A gateway without a namespace is in the
+// acorn-system namespace.
+func GatewayRef(cfg *apiv1.Config) (namespace, name string, ok bool) {
+	if cfg.Gateway == nil || *cfg.Gateway == "" {
+		return "", "", false
+	}
+	namespace, name, ok = strings.Cut(*cfg.Gateway, "/")
+	if !ok {
+		return system.Namespace, namespace, true
+	}
+	return namespace, name, true
+}
+
+// gatewayParent returns the reference of HTTPRoutes to the configured gateway and listener
+func gatewayParent(cfg *apiv1.Config) parentReference {
+	namespace, name, _ := GatewayRef(cfg)
+	parent := parentReference{
+		Group:     GatewayGroup,
+		Kind:      GatewayGVK.Kind,
+		Namespace: namespace,
+		Name:      name,
+	}
+	if cfg.GatewayListener != nil {
+		parent.SectionName = *cfg.GatewayListener
+	}
+	return parent
+}
+
+// NewHTTPRouteList returns an empty list that can be used to list HTTPRoutes
+func NewHTTPRouteList() *unstructured.UnstructuredList {
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(HTTPRouteGVK.GroupVersion().WithKind(HTTPRouteGVK.Kind + "List"))
+	return routes
+}
+
+// toUnstructured returns an object with the metadata and the spec, which must be a pointer to a struct
+func toUnstructured(gvk schema.GroupVersionKind, meta metav1.ObjectMeta, spec any) (*unstructured.Unstructured, error) {
+	specData, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": specData,
+		},
+	}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(meta.Name)
+	obj.SetNamespace(meta.Namespace)
+	obj.SetLabels(meta.Labels)
+	obj.SetAnnotations(meta.Annotations)
+	return obj, nil
+}
+
+func toHTTPRouteRules(rule networkingv1.IngressRule) (result []httpRouteRule) {
+	if rule.HTTP == nil {
+		return nil
+	}
+	for _, path := range rule.HTTP.Paths {
+		if path.Backend.Service == nil {
+			continue
+		}
+		pathType := "PathPrefix"
+		if path.PathType != nil && *path.PathType == networkingv1.PathTypeExact {
+			pathType = "Exact"
+		}
+		result = append(result, httpRouteRule{
+			Matches: []httpRouteMatch{
+				{
+					Path: httpPathMatch{
+						Type:  pathType,
+						Value: path.Path,
+					},
+				},
+			},
+			BackendRefs: []httpBackendRef{
+				{
+					Name: path.Backend.Service.Name,
+					Port: path.Backend.Service.Port.Number,
+				},
+			},
+		})
+	}
+	return
+}
+
+// toHTTPRoutes returns the HTTPRoutes that send the hosts of the ingress rules to the same backends as an Ingress
+// would. The hostnames of an HTTPRoute share all rules, so hosts with different paths get their own route. The hosts
+// of a route that have a TLS certificate are recorded on it, TLS itself is terminated by the listeners of the gateway.
+func toHTTPRoutes(app *v1.AppInstance, serviceName string, parent parentReference, tlsHosts map[string]bool, rules []networkingv1.IngressRule,
+	targets map[string]Target, labelMap, annotations map[string]string) (result []kclient.Object, _ error) {
+	var (
+		specs   []*httpRouteSpec
+		indexes = map[string]int{}
+	)
+	for _, rule := range rules {
+		routeRules := toHTTPRouteRules(rule)
+		if len(routeRules) == 0 {
+			continue
+		}
+		key, err := json.Marshal(routeRules)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := indexes[string(key)]
+		if !ok {
+			i = len(specs)
+			indexes[string(key)] = i
+			specs = append(specs, &httpRouteSpec{
+				ParentRefs: []parentReference{parent},
+				Rules:      routeRules,
+			})
+		}
+		if !slices.Contains(specs[i].Hostnames, rule.Host) {
+			specs[i].Hostnames = append(specs[i].Hostnames, rule.Host)
+		}
+	}
+
+	for i, spec := range specs {
+		var (
+			hostnames     = map[string]bool{}
+			routeTLSHosts []string
+		)
+		for _, hostname := range spec.Hostnames {
+			hostnames[hostname] = true
+			if tlsHosts[hostname] {
+				routeTLSHosts = append(routeTLSHosts, hostname)
+			}
+		}
+		routeTargets := map[string]Target{}
+		for hostname, target := range targets {
+			hostnameMinusPort, _, _ := strings.Cut(hostname, ":")
+			if hostnames[hostnameMinusPort] {
+				routeTargets[hostname] = target
+			}
+		}
+		targetJSON, err := json.Marshal(routeTargets)
+		if err != nil {
+			return nil, err
+		}
+
+		routeName := serviceName
+		if i > 0 {
+			routeName = name.SafeConcatName(serviceName, strconv.Itoa(i))
+		}
+		routeAnnotations := map[string]string{labels.AcornTargets: string(targetJSON)}
+		if len(routeTLSHosts) > 0 {
+			routeAnnotations[labels.AcornTLSHosts] = strings.Join(routeTLSHosts, ",")
+		}
+		route, err := toUnstructured(HTTPRouteGVK, metav1.ObjectMeta{
+			Name:        routeName,
+			Namespace:   app.Status.Namespace,
+			Labels:      labelMap,
+			Annotations: labels.Merge(annotations, routeAnnotations),
+		}, spec)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, route)
+	}
+	return
+}
+
+// publishGateway returns the HTTPRoutes that publish the rules of a service through the gateway. The gateway and its
+// listeners are managed by the admin, so the certificates of the hosts are only used to report the endpoints as
+// HTTPS. Certificates are not requested from cert-manager like they are for Ingresses, cert-manager only issues
+// certificates for the listeners of a gateway.
+func publishGateway(app *v1.AppInstance, cfg *apiv1.Config, serviceName string, rules []networkingv1.IngressRule,
+	targets map[string]Target, tlsCerts []*TLSCert, labelMap, annotations map[string]string) ([]kclient.Object, error) {
+	tlsHosts := map[string]bool{}
+	for _, tls := range getCertsForPublishedHosts(rules, filterCertsForPublishedHosts(rules, tlsCerts)) {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+	return toHTTPRoutes(app, serviceName, gatewayParent(cfg), tlsHosts, rules, targets, labelMap, annotations)
+}
+
+// HTTPRouteAccepted returns true if the gateway accepted the route
+func HTTPRouteAccepted(route *unstructured.Unstructured) bool {
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for _, parent := range parents {
+		parent, ok := parent.(map[string]any)
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(parent, "conditions")
+		for _, condition := range conditions {
+			condition, ok := condition.(map[string]any)
+			if ok && condition["type"] == "Accepted" && condition["status"] == "True" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package publish
+
+import (
+	"reflect"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGatewayRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		gateway       *string
+		wantNamespace string
+		wantName      string
+		wantOK        bool
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:    "empty",
+			gateway: &[]string{""}[0],
+		},
+		{
+			name:          "name only",
+			gateway:       &[]string{"public"}[0],
+			wantNamespace: "acorn-system",
+			wantName:      "public",
+			wantOK:        true,
+		},
+		{
+			name:          "namespace and name",
+			gateway:       &[]string{"gateway-system/public"}[0],
+			wantNamespace: "gateway-system",
+			wantName:      "public",
+			wantOK:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, name, ok := GatewayRef(&apiv1.Config{Gateway: tt.gateway})
+			if namespace != tt.wantNamespace || name != tt.wantName || ok != tt.wantOK {
+				t.Errorf("GatewayRef() = %v, %v, %v, want %v, %v, %v", namespace, name, ok, tt.wantNamespace, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestToHTTPRoutes(t *testing.T) {
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-namespace",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-created-namespace",
+		},
+	}
+	rules := []networkingv1.IngressRule{
+		rule("a.example.com", "web", 80),
+		rule("b.example.com", "web", 80),
+		rule("c.example.com", "web", 8080),
+		rule("a.example.com", "web", 80),
+	}
+	targets := map[string]Target{
+		"a.example.com":      {Port: 81, Service: "web"},
+		"b.example.com:8443": {Port: 81, Service: "web"},
+		"c.example.com":      {Port: 8081, Service: "web"},
+	}
+
+	parent := parentReference{
+		Group:       GatewayGroup,
+		Kind:        "Gateway",
+		Namespace:   "gateway-system",
+		Name:        "public",
+		SectionName: "https",
+	}
+	tlsHosts := map[string]bool{
+		"a.example.com": true,
+	}
+
+	routes, err := toHTTPRoutes(app, "web", parent, tlsHosts, rules, targets,
+		map[string]string{labels.AcornManaged: "true"}, map[string]string{"extra": "value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("toHTTPRoutes() returned %d routes, want 2", len(routes))
+	}
+
+	tests := []struct {
+		name          string
+		wantHostnames []any
+		wantPort      int64
+		wantTargets   string
+		wantTLSHosts  string
+	}{
+		{
+			name:          "web",
+			wantHostnames: []any{"a.example.com", "b.example.com"},
+			wantPort:      80,
+			wantTargets:   `{"a.example.com":{"port":81,"service":"web"},"b.example.com:8443":{"port":81,"service":"web"}}`,
+			wantTLSHosts:  "a.example.com",
+		},
+		{
+			name:          "web-1",
+			wantHostnames: []any{"c.example.com"},
+			wantPort:      8080,
+			wantTargets:   `{"c.example.com":{"port":8081,"service":"web"}}`,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := routes[i].(*unstructured.Unstructured)
+			if route.GetName() != tt.name || route.GetNamespace() != "app-created-namespace" {
+				t.Errorf("route = %s/%s, want app-created-namespace/%s", route.GetNamespace(), route.GetName(), tt.name)
+			}
+			if route.GroupVersionKind() != HTTPRouteGVK {
+				t.Errorf("route kind = %v, want %v", route.GroupVersionKind(), HTTPRouteGVK)
+			}
+			if got := route.GetAnnotations()[labels.AcornTargets]; got != tt.wantTargets {
+				t.Errorf("route targets = %v, want %v", got, tt.wantTargets)
+			}
+			if got := route.GetAnnotations()[labels.AcornTLSHosts]; got != tt.wantTLSHosts {
+				t.Errorf("route tls hosts = %v, want %v", got, tt.wantTLSHosts)
+			}
+			if got := route.GetAnnotations()["extra"]; got != "value" {
+				t.Errorf("route annotation extra = %v, want value", got)
+			}
+			hostnames, _, _ := unstructured.NestedSlice(route.Object, "spec", "hostnames")
+			if !reflect.DeepEqual(hostnames, tt.wantHostnames) {
+				t.Errorf("route hostnames = %v, want %v", hostnames, tt.wantHostnames)
+			}
+			parents, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+			wantParents := []any{
+				map[string]any{
+					"group":       GatewayGroup,
+					"kind":        "Gateway",
+					"namespace":   "gateway-system",
+					"name":        "public",
+					"sectionName": "https",
+				},
+			}
+			if !reflect.DeepEqual(parents, wantParents) {
+				t.Errorf("route parentRefs = %v, want %v", parents, wantParents)
+			}
+			rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+			wantRules := []any{
+				map[string]any{
+					"matches": []any{
+						map[string]any{
+							"path": map[string]any{
+								"type":  "PathPrefix",
+								"value": "/",
+							},
+						},
+					},
+					"backendRefs": []any{
+						map[string]any{
+							"name": "web",
+							"port": tt.wantPort,
+						},
+					},
+				},
+			}
+			if !reflect.DeepEqual(rules, wantRules) {
+				t.Errorf("route rules = %v, want %v", rules, wantRules)
+			}
+		})
+	}
+}
+
+func TestHTTPRouteAccepted(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]any{}}
+	if HTTPRouteAccepted(route) {
+		t.Errorf("HTTPRouteAccepted() = true for a route without status")
+	}
+
+	route.Object["status"] = map[string]any{
+		"parents": []any{
+			map[string]any{
+				"conditions": []any{
+					map[string]any{
+						"type":   "ResolvedRefs",
+						"status": "True",
+					},
+					map[string]any{
+						"type":   "Accepted",
+						"status": "True",
+					},
+				},
+			},
+		},
+	}
+	if !HTTPRouteAccepted(route) {
+		t.Errorf("HTTPRouteAccepted() = false for an accepted route")
+	}
+}
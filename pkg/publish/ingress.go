@@ -116,6 +116,16 @@ func getAppHash(serviceName, appName, appNamespace string) string {
 type Target struct {
 	Port    int32  `json:"port,omitempty"`
 	Service string `json:"service,omitempty"`
+	// Protocol is empty for http
+	Protocol v1.Protocol `json:"protocol,omitempty"`
+}
+
+func toTarget(serviceName string, port v1.PortDef) Target {
+	target := Target{Port: port.TargetPort, Service: serviceName}
+	if port.Protocol != v1.ProtocolHTTP {
+		target.Protocol = port.Protocol
+	}
+	return target
 }
 
 func Ingress(req router.Request, app *v1.AppInstance) (result []kclient.Object, _ error) {
@@ -137,6 +147,8 @@ func Ingress(req router.Request, app *v1.AppInstance) (result []kclient.Object,
 		}
 	}
 
+	_, _, useGateway := GatewayRef(cfg)
+
 	ps, err := ports.NewForIngressPublish(app)
 	if err != nil {
 		return nil, err
@@ -163,7 +175,7 @@ func Ingress(req router.Request, app *v1.AppInstance) (result []kclient.Object,
 			hostnames, ok := ps.Hostnames[port]
 			if ok {
 				for _, hostname := range hostnames {
-					targets[hostname] = Target{Port: port.TargetPort, Service: serviceName}
+					targets[hostname] = toTarget(serviceName, port)
 					rules = append(rules, rule(hostname, serviceName, port.Port))
 				}
 			}
@@ -177,7 +189,7 @@ func Ingress(req router.Request, app *v1.AppInstance) (result []kclient.Object,
 					return nil, err
 				}
 				hostnameMinusPort, _, _ := strings.Cut(hostname, ":")
-				targets[hostname] = Target{Port: port.TargetPort, Service: serviceName}
+				targets[hostname] = toTarget(serviceName, port)
 				rules = append(rules, rule(hostnameMinusPort, serviceName, port.Port))
 			}
 		}
@@ -187,6 +199,18 @@ func Ingress(req router.Request, app *v1.AppInstance) (result []kclient.Object,
 			return nil, err
 		}
 
+		labelMap, annotations := ingressLabelsAndAnnotations(serviceName, string(targetJSON), app, ps, rawPS)
+		annotations = labels.Merge(ports.ToIngressAnnotations(ps.PortsForService(serviceName)), annotations)
+
+		if useGateway {
+			objs, err := publishGateway(app, cfg, serviceName, rules, targets, tlsCerts, labelMap, annotations)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, objs...)
+			continue
+		}
+
 		filteredTLSCerts := filterCertsForPublishedHosts(rules, tlsCerts)
 		for i, tlsCert := range filteredTLSCerts {
 			originalSecret := &corev1.Secret{}
@@ -213,7 +237,6 @@ func Ingress(req router.Request, app *v1.AppInstance) (result []kclient.Object,
 		}
 
 		tlsIngress := getCertsForPublishedHosts(rules, filteredTLSCerts)
-		labelMap, annotations := ingressLabelsAndAnnotations(serviceName, string(targetJSON), app, ps, rawPS)
 		tlsIngress = setupCertManager(serviceName, annotations, rules, tlsIngress)
 
 		result = append(result, &networkingv1.Ingress{
@@ -30,6 +30,8 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 
 	ingressClassName := cfg.IngressClassName
 
+	_, _, useGateway := GatewayRef(cfg)
+
 	ps, err := ports.NewForRouterPublish(app)
 	if err != nil {
 		return nil, err
@@ -58,7 +60,7 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 			if ok {
 				for _, hostname := range hostnames {
 					targets[hostname] = Target{Port: port.TargetPort, Service: serviceName}
-					rules = append(rules, routerRule(hostname, router))
+					rules = append(rules, routerRule(hostname, serviceName, router))
 				}
 			}
 			svcName := serviceName
@@ -72,7 +74,7 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 				}
 				hostnameMinusPort, _, _ := strings.Cut(hostname, ":")
 				targets[hostname] = Target{Port: port.TargetPort, Service: serviceName}
-				rules = append(rules, routerRule(hostnameMinusPort, router))
+				rules = append(rules, routerRule(hostnameMinusPort, serviceName, router))
 			}
 		}
 
@@ -81,6 +83,17 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 			return nil, err
 		}
 
+		labelMap, annotations := routerIngressLabelsAndAnnotations(serviceName, string(targetJSON), app, ps, rawPS)
+
+		if useGateway {
+			objs, err := publishGateway(app, cfg, serviceName, rules, targets, tlsCerts, labelMap, annotations)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, objs...)
+			continue
+		}
+
 		filteredTLSCerts := filterCertsForPublishedHosts(rules, tlsCerts)
 		for i, tlsCert := range filteredTLSCerts {
 			originalSecret := &corev1.Secret{}
@@ -105,7 +118,6 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 		}
 
 		tlsIngress := getCertsForPublishedHosts(rules, filteredTLSCerts)
-		labelMap, annotations := routerIngressLabelsAndAnnotations(serviceName, string(targetJSON), app, ps, rawPS)
 		tlsIngress = setupCertManager(serviceName, annotations, rules, tlsIngress)
 
 		result = append(result, &networkingv1.Ingress{
@@ -127,22 +139,41 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 	return result, nil
 }
 
-func routerRule(host string, router v1.Router) networkingv1.IngressRule {
+// routerRule sends the paths of the router to the target services. Routes that split traffic between services go
+// through the router, which runs the load balancing.
+func routerRule(host, routerName string, router v1.Router) networkingv1.IngressRule {
 	rule := networkingv1.IngressRule{
 		Host: host,
 		IngressRuleValue: networkingv1.IngressRuleValue{
 			HTTP: &networkingv1.HTTPIngressRuleValue{},
 		},
 	}
+	// Routes with the same path are only tried in order by the router, so if any of them needs the router all of them
+	// are sent to it
+	viaRouter := map[string]bool{}
+	for _, route := range router.Routes {
+		if route.RequiresRouter() {
+			viaRouter[route.Path] = true
+		}
+	}
+
+	seen := map[string]bool{}
 	for _, route := range router.Routes {
-		if route.Path == "" || route.TargetServiceName == "" {
+		targetServiceName, port := route.TargetServiceName, route.TargetPort
+		if viaRouter[route.Path] {
+			targetServiceName, port = routerName, int(ports.RouterPortDef.Port)
+		}
+		if route.Path == "" || targetServiceName == "" {
 			continue
 		}
 		pathType := networkingv1.PathTypePrefix
 		if route.PathType == v1.PathTypeExact {
 			pathType = networkingv1.PathTypeExact
 		}
-		port := route.TargetPort
+		if seen[string(pathType)+route.Path] {
+			continue
+		}
+		seen[string(pathType)+route.Path] = true
 		if port == 0 {
 			port = 80
 		}
@@ -151,7 +182,7 @@ func routerRule(host string, router v1.Router) networkingv1.IngressRule {
 			PathType: &pathType,
 			Backend: networkingv1.IngressBackend{
 				Service: &networkingv1.IngressServiceBackend{
-					Name: route.TargetServiceName,
+					Name: targetServiceName,
 					Port: networkingv1.ServiceBackendPort{
 						Number: int32(port),
 					},
@@ -24,6 +24,7 @@ var (
 					"builders",
 					"images",
 					"volumes",
+					"volumebackups",
 					"containerreplicas",
 					"credentials",
 					"secrets",
@@ -51,6 +52,7 @@ var (
 					"images",
 					"credentials",
 					"secrets",
+					"volumebackups",
 				},
 			},
 			{
@@ -58,6 +60,8 @@ var (
 				Resources: []string{
 					"images/tag",
 					"apps/confirmupgrade",
+					"apps/jobrun",
+					"volumebackups/restore",
 				},
 			},
 			{
@@ -46,6 +46,7 @@ func handleDangerous(dangerous bool, perms []v1.Permissions) (bool, error) {
 	}
 
 	requests := ToRuleRequests(perms)
+	securityRequests := ToSecurityRequests(perms)
 
 	pterm.Warning.Println(
 		`This application would like to request the following runtime permissions.
@@ -53,15 +54,31 @@ This could be VERY DANGEROUS to the cluster if you do not trust this
 application. If you are unsure say no.`)
 	pterm.Println()
 
-	writer := table.NewWriter(tables.RuleRequests, false, "")
-	for _, request := range requests {
-		writer.Write(request)
+	if len(requests) > 0 {
+		writer := table.NewWriter(tables.RuleRequests, false, "")
+		for _, request := range requests {
+			writer.Write(request)
+		}
+
+		if err := writer.Close(); err != nil {
+			return false, err
+		}
+
+		pterm.Println()
 	}
 
-	if err := writer.Close(); err != nil {
-		return false, err
+	if len(securityRequests) > 0 {
+		writer := table.NewWriter(tables.SecurityRequests, false, "")
+		for _, request := range securityRequests {
+			writer.Write(request)
+		}
+
+		if err := writer.Close(); err != nil {
+			return false, err
+		}
+
+		pterm.Println()
 	}
 
-	pterm.Println()
 	return prompt.Bool("Do you want to allow this app to have these (POTENTIALLY DANGEROUS) permissions?", false)
 }
@@ -15,6 +15,23 @@ type RuleRequest struct {
 	Namespace    string
 }
 
+type SecurityRequest struct {
+	Service    string
+	Escalation string
+}
+
+func ToSecurityRequests(perms []v1.Permissions) (result []SecurityRequest) {
+	for _, perm := range perms {
+		for _, escalation := range perm.Escalations {
+			result = append(result, SecurityRequest{
+				Service:    perm.ServiceName,
+				Escalation: escalation,
+			})
+		}
+	}
+	return
+}
+
 func ToRuleRequests(perms []v1.Permissions) (result []RuleRequest) {
 	for _, perm := range perms {
 		result = append(result, clusterRulesToRequests(perm.ServiceName, perm.ClusterRules)...)
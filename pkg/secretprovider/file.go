@@ -0,0 +1,61 @@
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File reads secrets from a directory. A path that is a directory becomes a secret with a key for each file in it,
+// a path that is a file must contain a JSON object.
+type File struct {
+	Dir string
+}
+
+func (f *File) Fetch(ctx context.Context, path string) (map[string][]byte, error) {
+	// Cleaning the path as an absolute path keeps it inside the directory
+	fullPath := filepath.Join(f.Dir, filepath.Clean("/"+path))
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		content, err := readFile(fullPath, info)
+		if err != nil {
+			return nil, err
+		}
+		return fromJSON(content)
+	}
+
+	entries, err := os.ReadDir(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string][]byte{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		content, err := readFile(filepath.Join(fullPath, entry.Name()), info)
+		if err != nil {
+			return nil, err
+		}
+		result[entry.Name()] = content
+	}
+	return result, nil
+}
+
+func readFile(path string, info os.FileInfo) ([]byte, error) {
+	if info.Size() > maxResponseSize {
+		return nil, fmt.Errorf("file %s is larger than %d bytes", path, maxResponseSize)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,185 @@
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/acorn-io/acorn/pkg/config"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var referenceRegexp = regexp.MustCompile(`^([a-z][-a-z0-9]*)://([^#]*)(#(.+))?$`)
+
+// Provider fetches secret values from a system outside the cluster
+type Provider interface {
+	// Fetch returns the keys and values of the secret at path
+	Fetch(ctx context.Context, path string) (map[string][]byte, error)
+}
+
+// Reference points to a secret of an external provider in the form PROVIDER://PATH#KEY, the key is optional
+type Reference struct {
+	Provider string
+	Path     string
+	Key      string
+}
+
+func (r Reference) String() string {
+	if r.Key == "" {
+		return r.Provider + "://" + r.Path
+	}
+	return r.Provider + "://" + r.Path + "#" + r.Key
+}
+
+// ParseReference returns the reference to an external provider, false is returned if s is not a reference
+func ParseReference(s string) (Reference, bool) {
+	groups := referenceRegexp.FindStringSubmatch(s)
+	if groups == nil {
+		return Reference{}, false
+	}
+	return Reference{
+		Provider: groups[1],
+		Path:     strings.Trim(groups[2], "/"),
+		Key:      groups[4],
+	}, true
+}
+
+// IsReference returns true if s refers to a secret of an external provider
+func IsReference(s string) bool {
+	_, ok := ParseReference(s)
+	return ok
+}
+
+// AllNamespaces allows a provider to be used by apps in any namespace
+const AllNamespaces = "*"
+
+// Config is a provider and the namespaces of the apps that can bind its secrets
+type Config struct {
+	Name       string
+	Namespaces []string
+	Provider   Provider
+}
+
+// Allows returns true if apps in the namespace can bind secrets of the provider
+func (c *Config) Allows(namespace string) bool {
+	for _, allowed := range c.Namespaces {
+		if allowed == AllNamespaces || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// New creates the provider for a value in the form NAME=URL,namespace=NAMESPACE[,namespace=NAMESPACE...]. At least
+// one namespace must be listed so a provider is never shared with every app by accident, * allows all namespaces.
+func New(value string) (*Config, error) {
+	location, options, _ := strings.Cut(value, ",")
+	name, location, ok := strings.Cut(location, "=")
+	if !ok || name == "" || location == "" {
+		return nil, fmt.Errorf("invalid secret provider [%s], must be in the form NAME=URL,namespace=NAMESPACE", value)
+	}
+
+	result := &Config{
+		Name: name,
+	}
+	for _, option := range strings.Split(options, ",") {
+		if option == "" {
+			continue
+		}
+		key, namespace, _ := strings.Cut(option, "=")
+		if key != "namespace" || namespace == "" {
+			return nil, fmt.Errorf("invalid option [%s] for secret provider [%s], must be namespace=NAMESPACE", option, name)
+		}
+		result.Namespaces = append(result.Namespaces, namespace)
+	}
+	if len(result.Namespaces) == 0 {
+		return nil, fmt.Errorf("secret provider [%s] must list the namespaces that can use it with namespace=NAMESPACE", name)
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL for secret provider [%s]: %w", name, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		result.Provider = &HTTP{URL: location}
+	case "file":
+		result.Provider = &File{Dir: u.Path}
+	default:
+		return nil, fmt.Errorf("unsupported scheme [%s] for secret provider [%s], must be http, https or file", u.Scheme, name)
+	}
+	return result, nil
+}
+
+// Lookup returns the configured provider with the given name if apps in the namespace are allowed to use it
+func Lookup(ctx context.Context, c kclient.Reader, name, namespace string) (Provider, error) {
+	cfg, err := config.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range cfg.SecretProviders {
+		provider, err := New(value)
+		if err != nil {
+			return nil, err
+		}
+		if provider.Name != name {
+			continue
+		}
+		if !provider.Allows(namespace) {
+			return nil, fmt.Errorf("secret provider [%s] can not be used in namespace [%s]", name, namespace)
+		}
+		return provider.Provider, nil
+	}
+
+	return nil, fmt.Errorf("secret provider [%s] is not configured", name)
+}
+
+// Fetch returns the values of the referenced secret for an app in the namespace. If the reference has a key only
+// that key is returned.
+func Fetch(ctx context.Context, c kclient.Reader, ref Reference, namespace string) (map[string][]byte, error) {
+	provider, err := Lookup(ctx, c, ref.Provider, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := provider.Fetch(ctx, ref.Path)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", ref, err)
+	}
+
+	if ref.Key == "" {
+		return data, nil
+	}
+
+	value, ok := data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key [%s] not found in %s", ref.Key, ref)
+	}
+	return map[string][]byte{
+		ref.Key: value,
+	}, nil
+}
+
+// fromJSON converts a JSON object to secret data. String values are used as is and other values are JSON encoded.
+func fromJSON(content []byte) (map[string][]byte, error) {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("secret must be a JSON object: %w", err)
+	}
+
+	result := map[string][]byte{}
+	for key, value := range values {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			result[key] = []byte(s)
+		} else {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package secretprovider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, ok := ParseReference("vault://db/creds#password")
+	assert.True(t, ok)
+	assert.Equal(t, Reference{Provider: "vault", Path: "db/creds", Key: "password"}, ref)
+	assert.Equal(t, "vault://db/creds#password", ref.String())
+
+	ref, ok = ParseReference("local://app")
+	assert.True(t, ok)
+	assert.Equal(t, Reference{Provider: "local", Path: "app"}, ref)
+
+	_, ok = ParseReference("app.secret")
+	assert.False(t, ok)
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New("vault=https://vault.example.com/v1/secret,namespace=team-a,namespace=team-b")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		Name:       "vault",
+		Namespaces: []string{"team-a", "team-b"},
+		Provider:   &HTTP{URL: "https://vault.example.com/v1/secret"},
+	}, provider)
+	assert.True(t, provider.Allows("team-b"))
+	assert.False(t, provider.Allows("team-c"))
+
+	provider, err = New("local=file:///var/lib/secrets,namespace=*")
+	assert.NoError(t, err)
+	assert.Equal(t, &File{Dir: "/var/lib/secrets"}, provider.Provider)
+	assert.True(t, provider.Allows("team-c"))
+
+	_, err = New("vault")
+	assert.Error(t, err)
+
+	_, err = New("vault=https://vault.example.com/v1/secret")
+	assert.EqualError(t, err, "secret provider [vault] must list the namespaces that can use it with namespace=NAMESPACE")
+
+	_, err = New("vault=https://vault.example.com/v1/secret,project=team-a")
+	assert.Error(t, err)
+
+	_, err = New("vault=ftp://example.com,namespace=team-a")
+	assert.Error(t, err)
+}
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/secrets/db/creds" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"username": "admin", "password": "secret", "port": 5432}`))
+	}))
+	defer server.Close()
+
+	provider := &HTTP{URL: server.URL + "/secrets/"}
+	data, err := provider.Fetch(context.Background(), "db/creds")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+		"port":     []byte("5432"),
+	}, data)
+
+	_, err = provider.Fetch(context.Background(), "missing")
+	assert.EqualError(t, err, "unexpected status 404 Not Found")
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "password"), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "api.json"), []byte(`{"token": "abc"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &File{Dir: dir}
+
+	data, err := provider.Fetch(context.Background(), "db")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("secret")}, data)
+
+	data, err = provider.Fetch(context.Background(), "api.json")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"token": []byte("abc")}, data)
+
+	// Paths can not leave the directory
+	data, err = provider.Fetch(context.Background(), "../../db")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("secret")}, data)
+}
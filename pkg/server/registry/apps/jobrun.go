@@ -0,0 +1,126 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	kclient "github.com/acorn-io/acorn/pkg/k8sclient"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// jobRunTTLSeconds is how long a finished job run is kept around so its logs can still be read
+const jobRunTTLSeconds = 60 * 60
+
+// jobControllerLabels are added to the pod template by the Job controller and must not be copied to a new Job
+var jobControllerLabels = []string{
+	"controller-uid",
+	"job-name",
+	"batch.kubernetes.io/controller-uid",
+	"batch.kubernetes.io/job-name",
+}
+
+func NewJobRun(c client.WithWatch) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.JobRun{}).
+		WithCreate(&JobRunStrategy{
+			client: c,
+		}).Build()
+}
+
+type JobRunStrategy struct {
+	client client.WithWatch
+}
+
+func (s *JobRunStrategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	jobRun := obj.(*apiv1.JobRun)
+	ri, _ := request.RequestInfoFrom(ctx)
+
+	app := &v1.AppInstance{}
+	err := s.client.Get(ctx, kclient.ObjectKey{Namespace: ri.Namespace, Name: ri.Name}, app)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := app.Status.AppSpec.Jobs[jobRun.JobName]; !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("app [%s] does not have a job named [%s]", app.Name, jobRun.JobName))
+	}
+
+	template, err := s.jobTemplate(ctx, app, jobRun.JobName)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: jobRun.JobName + "-run-",
+			Namespace:    app.Status.Namespace,
+			Labels:       jobRunLabels(app, jobRun.JobName, nil),
+		},
+		Spec: *template,
+	}
+	job.Spec.Selector = nil
+	job.Spec.ManualSelector = nil
+	job.Spec.TTLSecondsAfterFinished = &[]int32{jobRunTTLSeconds}[0]
+	job.Spec.Template.Labels = jobRunLabels(app, jobRun.JobName, job.Spec.Template.Labels)
+
+	if err := s.client.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	jobRun.Job = job.Name
+	return jobRun, nil
+}
+
+// jobRunLabels returns the labels of a run of the job. The run is not managed by the app and is not the deployed job,
+// so the labels the app and job status select by are replaced with the job run label.
+func jobRunLabels(app *v1.AppInstance, jobName string, templateLabels map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range templateLabels {
+		result[k] = v
+	}
+	for _, key := range append(jobControllerLabels, labels.AcornManaged, labels.AcornJobName) {
+		delete(result, key)
+	}
+	result[labels.AcornAppName] = app.Name
+	result[labels.AcornAppNamespace] = app.Namespace
+	result[labels.AcornJobRun] = jobName
+	return result
+}
+
+// jobTemplate returns the spec of the Job that the controller rendered for the job, or the template of the CronJob
+// if the job has a schedule
+func (s *JobRunStrategy) jobTemplate(ctx context.Context, app *v1.AppInstance, jobName string) (*batchv1.JobSpec, error) {
+	if app.Status.Namespace == "" {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("app [%s] has not been deployed", app.Name))
+	}
+
+	cronJob := &batchv1.CronJob{}
+	err := s.client.Get(ctx, kclient.ObjectKey{Namespace: app.Status.Namespace, Name: jobName}, cronJob)
+	if err == nil {
+		return &cronJob.Spec.JobTemplate.Spec, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	job := &batchv1.Job{}
+	err = s.client.Get(ctx, kclient.ObjectKey{Namespace: app.Status.Namespace, Name: jobName}, job)
+	if apierrors.IsNotFound(err) {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("job [%s] of app [%s] has not been deployed", jobName, app.Name))
+	} else if err != nil {
+		return nil, err
+	}
+	return &job.Spec, nil
+}
+
+func (s *JobRunStrategy) New() types.Object {
+	return &apiv1.JobRun{}
+}
@@ -0,0 +1,101 @@
+package apps
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestJobRunLabels(t *testing.T) {
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-namespace",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-created-namespace",
+			AppSpec: v1.AppSpec{
+				Jobs: map[string]v1.Container{
+					"migrate": {},
+				},
+			},
+		},
+	}
+	deployed := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "app-created-namespace",
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						labels.AcornManaged:       "true",
+						labels.AcornAppName:       "app-name",
+						labels.AcornAppNamespace:  "app-namespace",
+						labels.AcornJobName:       "migrate",
+						labels.AcornContainerName: "",
+						"controller-uid":          "1234",
+						"job-name":                "migrate",
+					},
+				},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(app, deployed).Build()
+	ctx := request.WithRequestInfo(context.Background(), &request.RequestInfo{
+		Namespace: "app-namespace",
+		Name:      "app-name",
+	})
+
+	strategy := &JobRunStrategy{client: c}
+	_, err := strategy.Create(ctx, &apiv1.JobRun{JobName: "migrate"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := c.List(ctx, jobs, &kclient.ListOptions{
+		Namespace:     "app-created-namespace",
+		LabelSelector: klabels.SelectorFromSet(map[string]string{labels.AcornJobRun: "migrate"}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, jobs.Items, 1) {
+		return
+	}
+
+	run := jobs.Items[0]
+	assert.Equal(t, map[string]string{
+		labels.AcornAppName:      "app-name",
+		labels.AcornAppNamespace: "app-namespace",
+		labels.AcornJobRun:       "migrate",
+	}, run.Labels)
+	assert.Equal(t, map[string]string{
+		labels.AcornAppName:       "app-name",
+		labels.AcornAppNamespace:  "app-namespace",
+		labels.AcornContainerName: "",
+		labels.AcornJobRun:        "migrate",
+	}, run.Spec.Template.Labels)
+
+	// The app and job status only select the pods of the deployed job
+	jobStatusSelector := klabels.SelectorFromSet(map[string]string{
+		labels.AcornManaged: "true",
+		labels.AcornJobName: "migrate",
+	})
+	assert.False(t, jobStatusSelector.Matches(klabels.Set(run.Spec.Template.Labels)))
+	assert.True(t, jobStatusSelector.Matches(klabels.Set(deployed.Spec.Template.Labels)))
+}
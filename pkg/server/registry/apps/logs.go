@@ -72,6 +72,7 @@ func (i *Logs) Connect(ctx context.Context, id string, options runtime.Object, r
 			Tail:             opts.Tail,
 			Follow:           opts.Follow,
 			ContainerReplica: opts.ContainerReplica,
+			JobRun:           opts.JobRun,
 		})
 		if err != nil {
 			output <- log.Message{
@@ -95,6 +96,7 @@ func (i *Logs) Connect(ctx context.Context, id string, options runtime.Object, r
 				Line:          message.Line,
 				ContainerName: message.ContainerName,
 				Time:          metav1.NewTime(message.Time),
+				JobRunResult:  string(message.JobRunResult),
 			}
 
 			if message.Pod != nil {
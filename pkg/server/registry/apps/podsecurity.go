@@ -0,0 +1,94 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/baaah/pkg/merr"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	podSecurityBaseline   = "baseline"
+	podSecurityRestricted = "restricted"
+)
+
+var (
+	// baselineCapabilities are the capabilities the baseline PodSecurity profile allows to be added
+	baselineCapabilities = []string{
+		"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD", "NET_BIND_SERVICE",
+		"SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+	}
+	restrictedCapabilities = []string{"NET_BIND_SERVICE"}
+)
+
+// checkPodSecurity checks the security of the containers against the PodSecurity profile that is enforced on the
+// app namespace. Approving an escalation does not help if the cluster will refuse to run the pod.
+func (s *Validator) checkPodSecurity(ctx context.Context, appSpec *v1.AppSpec) error {
+	cfg, err := config.Get(ctx, s.client)
+	if err != nil {
+		return err
+	}
+
+	if !*cfg.SetPodSecurityEnforceProfile {
+		return nil
+	}
+
+	return checkPodSecurityProfile(cfg.PodSecurityEnforceProfile, appSpec)
+}
+
+func checkPodSecurityProfile(profile string, appSpec *v1.AppSpec) error {
+	var errs []error
+	for _, containers := range []map[string]v1.Container{appSpec.Containers, appSpec.Jobs} {
+		for _, entry := range typed.Sorted(containers) {
+			errs = append(errs, checkContainerSecurity(profile, entry.Key, entry.Value.Security))
+			for _, sidecar := range typed.Sorted(entry.Value.Sidecars) {
+				errs = append(errs, checkContainerSecurity(profile, sidecar.Key, sidecar.Value.Security))
+			}
+		}
+	}
+	return merr.NewErrors(errs...)
+}
+
+func checkContainerSecurity(profile, containerName string, security *v1.Security) error {
+	if security == nil {
+		return nil
+	}
+
+	var allowedCapabilities []string
+	switch profile {
+	case podSecurityBaseline:
+		allowedCapabilities = baselineCapabilities
+	case podSecurityRestricted:
+		allowedCapabilities = restrictedCapabilities
+		if security.RunAsUser != nil && *security.RunAsUser == 0 {
+			return fmt.Errorf("container [%s] runs as root which is not allowed by the PodSecurity profile [%s]", containerName, profile)
+		}
+		if security.RunAsNonRoot != nil && !*security.RunAsNonRoot {
+			return fmt.Errorf("container [%s] sets runAsNonRoot to false which is not allowed by the PodSecurity profile [%s]", containerName, profile)
+		}
+	default:
+		// The privileged profile allows everything
+		return nil
+	}
+
+	for _, escalation := range security.Escalations() {
+		if capability, ok := cutCapability(escalation); ok && slices.Contains(allowedCapabilities, capability) {
+			continue
+		}
+		return fmt.Errorf("container [%s] requests [%s] which is not allowed by the PodSecurity profile [%s]", containerName, escalation, profile)
+	}
+
+	return nil
+}
+
+func cutCapability(escalation string) (string, bool) {
+	if !strings.HasPrefix(escalation, v1.EscalationCapabilityPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(escalation, v1.EscalationCapabilityPrefix), true
+}
@@ -6,16 +6,20 @@ import (
 	"fmt"
 	"strings"
 
+	api "github.com/acorn-io/acorn/pkg/apis/api.acorn.io"
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/autoupgrade"
 	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/expose"
 	"github.com/acorn-io/acorn/pkg/pullsecret"
+	"github.com/acorn-io/acorn/pkg/secretprovider"
 	"github.com/acorn-io/acorn/pkg/tags"
 	"github.com/acorn-io/baaah/pkg/merr"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"golang.org/x/exp/slices"
 	authv1 "k8s.io/api/authorization/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -39,7 +43,10 @@ func NewValidator(client kclient.Client, clientFactory *client.Factory) *Validat
 }
 
 func (s *Validator) Validate(ctx context.Context, obj runtime.Object) (result field.ErrorList) {
-	params := obj.(*apiv1.App)
+	var (
+		params  = obj.(*apiv1.App)
+		appSpec *v1.AppSpec
+	)
 
 	if _, isPattern := autoupgrade.AutoUpgradePattern(params.Spec.Image); !isPattern {
 		image, local, err := s.resolveLocalImage(ctx, params.Namespace, params.Spec.Image)
@@ -55,12 +62,19 @@ func (s *Validator) Validate(ctx context.Context, obj runtime.Object) (result fi
 			}
 		}
 
-		permsFromImage, err := s.getPermissions(ctx, image, params)
+		appSpec, err = s.getAppSpec(ctx, image, params)
 		if err != nil {
 			result = append(result, field.Invalid(field.NewPath("spec", "permissions"), params.Spec.Permissions, err.Error()))
 			return
 		}
 
+		if err := s.checkPodSecurity(ctx, appSpec); err != nil {
+			result = append(result, field.Invalid(field.NewPath("spec", "image"), params.Spec.Image, err.Error()))
+			return
+		}
+
+		permsFromImage := buildPermissions(appSpec)
+
 		if err := s.checkRequestedPermsSatisfyImagePerms(permsFromImage, params.Spec.Permissions); err != nil {
 			result = append(result, field.Invalid(field.NewPath("spec", "permissions"), params.Spec.Permissions, err.Error()))
 			return
@@ -71,9 +85,58 @@ func (s *Validator) Validate(ctx context.Context, obj runtime.Object) (result fi
 		result = append(result, field.Invalid(field.NewPath("spec", "permissions"), params.Spec.Permissions, err.Error()))
 	}
 
+	if err := s.checkComputeClasses(ctx, appSpec, params); err != nil {
+		result = append(result, field.Invalid(field.NewPath("spec", "computeClass"), params.Spec.ComputeClass, err.Error()))
+	}
+
+	if err := s.checkSecretProviders(ctx, params); err != nil {
+		result = append(result, field.Invalid(field.NewPath("spec", "secrets"), params.Spec.Secrets, err.Error()))
+	}
+
+	if err := checkExternalServices(appSpec, params); err != nil {
+		result = append(result, field.Invalid(field.NewPath("spec", "externalServices"), params.Spec.ExternalServices, err.Error()))
+	}
+
 	return result
 }
 
+// checkExternalServices ensures that only services declared by the app are bound and that they can be created with
+// the addresses and ports bound to them, such as a hostname that can not be bound with a different port. appSpec is
+// nil if the image could not be resolved.
+func checkExternalServices(appSpec *v1.AppSpec, app *apiv1.App) error {
+	if appSpec == nil {
+		return nil
+	}
+	for _, binding := range app.Spec.ExternalServices {
+		if _, ok := appSpec.Services[binding.Target]; !ok {
+			return fmt.Errorf("service [%s] is not declared in the services section of the app", binding.Target)
+		}
+	}
+	_, err := expose.ExternalServices(&v1.AppInstance{
+		ObjectMeta: app.ObjectMeta,
+		Spec:       app.Spec,
+		Status: v1.AppInstanceStatus{
+			AppSpec: *appSpec,
+		},
+	})
+	return err
+}
+
+// checkSecretProviders ensures that the providers of secrets bound from outside the cluster are configured and can be
+// used by apps in the namespace of the app
+func (s *Validator) checkSecretProviders(ctx context.Context, app *apiv1.App) error {
+	for _, binding := range app.Spec.Secrets {
+		ref, ok := secretprovider.ParseReference(binding.Secret)
+		if !ok {
+			continue
+		}
+		if _, err := secretprovider.Lookup(ctx, s.client, ref.Provider, app.Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Validator) ValidateUpdate(ctx context.Context, obj, old runtime.Object) (result field.ErrorList) {
 	newParams := obj.(*apiv1.App)
 	return s.Validate(ctx, newParams)
@@ -224,13 +287,13 @@ func (s *Validator) checkRequestedPermsSatisfyImagePerms(perms []v1.Permissions,
 
 	permsError := &client.ErrRulesNeeded{Permissions: []v1.Permissions{}}
 	for _, perm := range perms {
-		if len(perm.ClusterRules) == 0 && len(perm.Rules) == 0 {
+		if len(perm.ClusterRules) == 0 && len(perm.Rules) == 0 && len(perm.Escalations) == 0 {
 			continue
 		}
 
-		if specPerms := v1.FindPermission(perm.ServiceName, requestedPerms); !specPerms.HasRules() ||
-			!equality.Semantic.DeepEqual(perm.ClusterRules, specPerms.Get().ClusterRules) ||
-			!equality.Semantic.DeepEqual(perm.ClusterRules, specPerms.Get().ClusterRules) {
+		if specPerms := v1.FindPermission(perm.ServiceName, requestedPerms); !equality.Semantic.DeepEqual(perm.ClusterRules, specPerms.ClusterRules) ||
+			!equality.Semantic.DeepEqual(perm.Rules, specPerms.Rules) ||
+			!equality.Semantic.DeepEqual(perm.Escalations, specPerms.Escalations) {
 			permsError.Permissions = append(permsError.Permissions, perm)
 			continue
 		}
@@ -243,7 +306,8 @@ func (s *Validator) checkRequestedPermsSatisfyImagePerms(perms []v1.Permissions,
 }
 
 // checkPermissionsForPrivilegeEscalation is an actual RBAC check to prevent privilege escalation. The user making the request must have the
-// permissions that they are requesting the app gets
+// permissions that they are requesting the app gets. Security escalations, such as privileged containers or added capabilities, are not
+// RBAC permissions so the user must be explicitly allowed to create the apps/escalate subresource with the escalation as resource name.
 func (s *Validator) checkPermissionsForPrivilegeEscalation(ctx context.Context, requestedPerms []v1.Permissions) error {
 	user, ok := request.UserFrom(ctx)
 	if !ok {
@@ -273,29 +337,49 @@ func (s *Validator) checkPermissionsForPrivilegeEscalation(ctx context.Context,
 		if err := s.checkRules(ctx, sar, perm.Rules, ns); err != nil {
 			errs = append(errs, err)
 		}
+
+		if err := s.checkRules(ctx, sar, escalationRules(perm.Escalations), ns); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	return merr.NewErrors(errs...)
 }
 
-func (s *Validator) getPermissions(ctx context.Context, image string, app *apiv1.App) (result []v1.Permissions, _ error) {
+// escalationRules returns the rules a user needs to deploy an app with the escalations
+func escalationRules(escalations []string) (result []v1.PolicyRule) {
+	for _, escalation := range escalations {
+		result = append(result, v1.PolicyRule{
+			APIGroups:     []string{api.Group},
+			Verbs:         []string{"create"},
+			Resources:     []string{"apps/escalate"},
+			ResourceNames: []string{escalation},
+		})
+	}
+	return
+}
+
+func (s *Validator) getAppSpec(ctx context.Context, image string, app *apiv1.App) (*v1.AppSpec, error) {
 	details, err := s.clientFactory.Namespace("", app.Namespace).ImageDetails(ctx, image,
 		&client.ImageDetailsOptions{
 			Profiles:   app.Spec.Profiles,
 			DeployArgs: app.Spec.DeployArgs})
 
 	if err != nil {
-		return result, err
+		return nil, err
 	}
 
 	if details.ParseError != "" {
-		return result, errors.New(details.ParseError)
+		return nil, errors.New(details.ParseError)
 	}
 
-	result = append(result, buildPermissionsFrom(details.AppSpec.Containers)...)
-	result = append(result, buildPermissionsFrom(details.AppSpec.Jobs)...)
+	return details.AppSpec, nil
+}
 
-	return result, nil
+func buildPermissions(appSpec *v1.AppSpec) (result []v1.Permissions) {
+	result = append(result, buildPermissionsFrom(appSpec.Containers)...)
+	result = append(result, buildPermissionsFrom(appSpec.Jobs)...)
+	return result
 }
 
 func buildPermissionsFrom(containers map[string]v1.Container) []v1.Permissions {
@@ -305,19 +389,95 @@ func buildPermissionsFrom(containers map[string]v1.Container) []v1.Permissions {
 			ServiceName:  entry.Key,
 			ClusterRules: entry.Value.Permissions.Get().ClusterRules,
 			Rules:        entry.Value.Permissions.Get().Rules,
+			Escalations:  entry.Value.Security.Escalations(),
 		}
 
 		for _, sidecar := range typed.Sorted(entry.Value.Sidecars) {
 			entryPermissions.ClusterRules = append(entryPermissions.ClusterRules, sidecar.Value.Permissions.Get().ClusterRules...)
 			entryPermissions.Rules = append(entryPermissions.Rules, sidecar.Value.Permissions.Get().Rules...)
+			entryPermissions.Escalations = append(entryPermissions.Escalations, sidecar.Value.Security.Escalations()...)
 		}
 
+		// Sidecars share the pod so the same escalation is only requested once
+		slices.Sort(entryPermissions.Escalations)
+		entryPermissions.Escalations = slices.Compact(entryPermissions.Escalations)
+
 		permissions = append(permissions, entryPermissions)
 	}
 
 	return permissions
 }
 
+// checkComputeClasses checks that every compute class referenced by the app exists and that the memory and cpu of
+// each container is within the range allowed by its class. appSpec is nil if the image could not be resolved
+// yet, in which case only the classes set on the app are checked.
+func (s *Validator) checkComputeClasses(ctx context.Context, appSpec *v1.AppSpec, app *apiv1.App) error {
+	classes := &v1.ComputeClassInstanceList{}
+	if err := s.client.List(ctx, classes); err != nil {
+		return err
+	}
+
+	byName := map[string]*v1.ComputeClassInstance{}
+	for i := range classes.Items {
+		byName[classes.Items[i].Name] = &classes.Items[i]
+	}
+
+	for _, className := range typed.SortedValues(app.Spec.ComputeClass) {
+		if _, ok := byName[className]; !ok {
+			return fmt.Errorf("compute class [%s] not found", className)
+		}
+	}
+
+	if appSpec == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, containers := range []map[string]v1.Container{appSpec.Containers, appSpec.Jobs} {
+		for _, entry := range typed.Sorted(containers) {
+			class := classes.Default()
+			if className := app.Spec.ComputeClass.Get(entry.Key); className != "" {
+				class = byName[className]
+			} else if entry.Value.Class != "" {
+				class = byName[entry.Value.Class]
+				if class == nil {
+					errs = append(errs, fmt.Errorf("compute class [%s] for container [%s] not found", entry.Value.Class, entry.Key))
+					continue
+				}
+			}
+			if class == nil {
+				continue
+			}
+
+			errs = append(errs, checkContainerResources(appSpec, app, class, entry.Key, entry.Value))
+			for _, sidecar := range typed.Sorted(entry.Value.Sidecars) {
+				errs = append(errs, checkContainerResources(appSpec, app, class, sidecar.Key, sidecar.Value))
+			}
+		}
+	}
+
+	return merr.NewErrors(errs...)
+}
+
+// checkContainerResources checks the memory and cpu the container will be deployed with, which includes the class
+// defaults and the memory backed volumes the container mounts.
+func checkContainerResources(appSpec *v1.AppSpec, app *apiv1.App, class *v1.ComputeClassInstance, containerName string, container v1.Container) error {
+	memory := class.Memory.WithDefault(app.Spec.Memory.Effective(containerName, container.Memory))
+	if size := appSpec.MemoryVolumeSize(&app.Spec, container); !size.IsZero() {
+		var err error
+		if memory, err = memory.Add(size); err != nil {
+			return fmt.Errorf("memory of container [%s]: %w", containerName, err)
+		}
+	}
+	if err := class.Memory.Check(memory); err != nil {
+		return fmt.Errorf("memory of container [%s] is not allowed by compute class [%s]: %w", containerName, class.Name, err)
+	}
+	if err := class.CPU.Check(class.CPU.WithDefault(app.Spec.CPU.Effective(containerName, container.CPU))); err != nil {
+		return fmt.Errorf("cpu of container [%s] is not allowed by compute class [%s]: %w", containerName, class.Name, err)
+	}
+	return nil
+}
+
 func (s *Validator) resolveLocalImage(ctx context.Context, namespace, image string) (string, bool, error) {
 	localImage, err := s.clientFactory.Namespace("", namespace).ImageGet(ctx, image)
 	if apierrors.IsNotFound(err) {
@@ -0,0 +1,168 @@
+package apps
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/stretchr/testify/assert"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// sarClient answers SubjectAccessReviews by allowing the resources in allowed
+type sarClient struct {
+	kclient.Client
+	allowed map[string]bool
+}
+
+func (c *sarClient) Create(_ context.Context, obj kclient.Object, _ ...kclient.CreateOption) error {
+	sar := obj.(*authv1.SubjectAccessReview)
+	attrs := sar.Spec.ResourceAttributes
+	sar.Status.Allowed = c.allowed[attrs.Resource+"/"+attrs.Subresource+":"+attrs.Name]
+	return nil
+}
+
+func TestCheckPermissionsForPrivilegeEscalation(t *testing.T) {
+	ctx := request.WithNamespace(request.WithUser(context.Background(), &user.DefaultInfo{Name: "user"}), "app-namespace")
+	validator := &Validator{
+		client: &sarClient{
+			allowed: map[string]bool{
+				"apps/escalate:" + v1.EscalationHostNetwork: true,
+			},
+		},
+	}
+
+	err := validator.checkPermissionsForPrivilegeEscalation(ctx, []v1.Permissions{
+		{
+			ServiceName: "web",
+			Escalations: []string{v1.EscalationHostNetwork},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = validator.checkPermissionsForPrivilegeEscalation(ctx, []v1.Permissions{
+		{
+			ServiceName: "web",
+			Escalations: []string{v1.EscalationHostNetwork, v1.EscalationPrivileged},
+		},
+	})
+	assert.EqualError(t, err, `not authorized: {"verbs":["create"],"apiGroups":["api.acorn.io"],"resources":["apps/escalate"],"resourceNames":["privileged"]}`)
+}
+
+func TestCheckRequestedPermsSatisfyImagePerms(t *testing.T) {
+	var (
+		validator = &Validator{}
+		readPods  = v1.PolicyRule{Verbs: []string{"get"}, Resources: []string{"pods"}}
+		readAll   = v1.PolicyRule{Verbs: []string{"get"}, Resources: []string{"*"}}
+		perms     = []v1.Permissions{
+			{
+				ServiceName: "web",
+				Rules:       []v1.PolicyRule{readPods},
+				ClusterRules: []v1.ClusterPolicyRule{
+					{PolicyRule: rbacv1.PolicyRule(readPods)},
+				},
+			},
+		}
+	)
+
+	assert.NoError(t, validator.checkRequestedPermsSatisfyImagePerms(perms, perms))
+
+	// Only the namespaced rules differ, the image's rules were not granted
+	err := validator.checkRequestedPermsSatisfyImagePerms(perms, []v1.Permissions{
+		{
+			ServiceName:  "web",
+			Rules:        []v1.PolicyRule{readAll},
+			ClusterRules: perms[0].ClusterRules,
+		},
+	})
+	var rulesNeeded *client.ErrRulesNeeded
+	if assert.ErrorAs(t, err, &rulesNeeded) {
+		assert.Equal(t, perms, rulesNeeded.Permissions)
+	}
+
+	err = validator.checkRequestedPermsSatisfyImagePerms([]v1.Permissions{
+		{
+			ServiceName: "web",
+			Escalations: []string{v1.EscalationPrivileged},
+		},
+	}, nil)
+	assert.ErrorAs(t, err, &rulesNeeded)
+}
+
+func TestCheckExternalServices(t *testing.T) {
+	appSpec := &v1.AppSpec{
+		Services: map[string]v1.Service{
+			"db": {
+				Address: "db.example.com",
+				Ports: []v1.PortDef{
+					{
+						Port: 5432,
+					},
+				},
+			},
+		},
+	}
+
+	app := &apiv1.App{}
+	assert.NoError(t, checkExternalServices(appSpec, app))
+
+	app.Spec.ExternalServices = []v1.ExternalServiceBinding{
+		{
+			Target:  "db",
+			Address: "10.0.0.7",
+			Port:    15432,
+		},
+	}
+	assert.NoError(t, checkExternalServices(appSpec, app))
+
+	app.Spec.ExternalServices[0].Address = "other.example.com"
+	assert.EqualError(t, checkExternalServices(appSpec, app), "service [db] with hostname [other.example.com] can not map port 5432 to 15432, an IP address must be used")
+
+	app.Spec.ExternalServices[0].Target = "cache"
+	assert.EqualError(t, checkExternalServices(appSpec, app), "service [cache] is not declared in the services section of the app")
+}
+
+func TestCheckSecretProviders(t *testing.T) {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      system.ConfigName,
+			Namespace: system.Namespace,
+		},
+		Data: map[string]string{
+			"config": `{"secretProviders": ["vault=https://vault.example.com/v1/secret,namespace=team-a"]}`,
+		},
+	}
+	validator := &Validator{
+		client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(config).Build(),
+	}
+
+	app := &apiv1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "team-a",
+		},
+		Spec: v1.AppInstanceSpec{
+			Secrets: []v1.SecretBinding{
+				{
+					Secret: "vault://db/creds",
+					Target: "db",
+				},
+			},
+		},
+	}
+	assert.NoError(t, validator.checkSecretProviders(context.Background(), app))
+
+	app.Namespace = "team-b"
+	assert.EqualError(t, validator.checkSecretProviders(context.Background(), app), "secret provider [vault] can not be used in namespace [team-b]")
+}
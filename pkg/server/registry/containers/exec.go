@@ -7,9 +7,12 @@ import (
 	"net/http/httputil"
 	"time"
 
+	api "github.com/acorn-io/acorn/pkg/apis/api.acorn.io"
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/k8sclient"
 	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
 	"github.com/acorn-io/baaah/pkg/restconfig"
 	"github.com/acorn-io/baaah/pkg/watcher"
 	"github.com/acorn-io/mink/pkg/strategy"
@@ -19,6 +22,7 @@ import (
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	registryrest "k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/client-go/kubernetes"
@@ -99,6 +103,9 @@ func (c *ContainerExec) connect(podName, podNamespace, containerName string, exe
 
 func (c *ContainerExec) Connect(ctx context.Context, id string, options runtime.Object, r registryrest.Responder) (http.Handler, error) {
 	execOpt := options.(*apiv1.ContainerReplicaExecOptions)
+	if id == "_" && execOpt.Volume != "" {
+		return c.execVolume(ctx, execOpt)
+	}
 	if id == "_" && execOpt.DebugImage != "" {
 		return c.execNew(ctx, execOpt)
 	}
@@ -144,11 +151,10 @@ func command(args []string) []string {
 
 func (c *ContainerExec) execNew(ctx context.Context, execOpts *apiv1.ContainerReplicaExecOptions) (http.Handler, error) {
 	ns, _ := request.NamespaceFrom(ctx)
-	execName := "shell"
-	pods := c.k8s.CoreV1().Pods(ns)
-	pod, err := pods.Create(ctx, &corev1.Pod{
+	return c.execPod(ctx, &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "debug-shell-",
+			Namespace:    ns,
 			Labels: map[string]string{
 				labels.AcornDebugShell: "true",
 			},
@@ -156,14 +162,91 @@ func (c *ContainerExec) execNew(ctx context.Context, execOpts *apiv1.ContainerRe
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
 				{
-					Name:    execName,
+					Name:    "shell",
 					Image:   execOpts.DebugImage,
 					Command: []string{"sleep", "9999999"},
 				},
 			},
 			RestartPolicy: corev1.RestartPolicyNever,
 		},
-	}, metav1.CreateOptions{})
+	}, execOpts)
+}
+
+// execVolume runs the command in a new pod that mounts the claim the volume is bound to. If the claim is in use the
+// pod is placed on the same node so that ReadWriteOnce volumes can be mounted.
+func (c *ContainerExec) execVolume(ctx context.Context, execOpts *apiv1.ContainerReplicaExecOptions) (http.Handler, error) {
+	ns, _ := request.NamespaceFrom(ctx)
+	pv := &corev1.PersistentVolume{}
+	if err := c.client.Get(ctx, k8sclient.ObjectKey{Name: execOpts.Volume}, pv); apierror.IsNotFound(err) ||
+		(err == nil && pv.Labels[labels.AcornAppNamespace] != ns) {
+		return nil, apierror.NewNotFound(schema.GroupResource{
+			Group:    api.Group,
+			Resource: "volumes",
+		}, execOpts.Volume)
+	} else if err != nil {
+		return nil, err
+	}
+	if pv.Spec.ClaimRef == nil || pv.Status.Phase != corev1.VolumeBound {
+		return nil, apierror.NewBadRequest(fmt.Sprintf("volume [%s] is not bound to an app", execOpts.Volume))
+	}
+
+	nodeName, err := volumebackup.ClaimNodeName(ctx, c.client, pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	image := execOpts.DebugImage
+	if image == "" {
+		image = system.DefaultImage()
+	}
+
+	return c.execPod(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "volume-exec-",
+			Namespace:    pv.Spec.ClaimRef.Namespace,
+			Labels: map[string]string{
+				labels.AcornManaged:    "true",
+				labels.AcornDebugShell: "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            "volume",
+					Image:           image,
+					Command:         []string{"sleep", "9999999"},
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "volume",
+							MountPath: system.VolumeExecMountPath,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "volume",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pv.Spec.ClaimRef.Name,
+						},
+					},
+				},
+			},
+			NodeName:           nodeName,
+			EnableServiceLinks: new(bool),
+			RestartPolicy:      corev1.RestartPolicyNever,
+		},
+	}, execOpts)
+}
+
+// execPod creates the pod, waits for its first container to run and connects to it. The pod is deleted once the
+// request is done.
+func (c *ContainerExec) execPod(ctx context.Context, pod *corev1.Pod, execOpts *apiv1.ContainerReplicaExecOptions) (http.Handler, error) {
+	execName := pod.Spec.Containers[0].Name
+	pods := c.k8s.CoreV1().Pods(pod.Namespace)
+	pod, err := pods.Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
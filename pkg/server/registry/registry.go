@@ -15,6 +15,7 @@ import (
 	"github.com/acorn-io/acorn/pkg/server/registry/info"
 	"github.com/acorn-io/acorn/pkg/server/registry/projects"
 	"github.com/acorn-io/acorn/pkg/server/registry/secrets"
+	"github.com/acorn-io/acorn/pkg/server/registry/volumebackups"
 	"github.com/acorn-io/acorn/pkg/server/registry/volumes"
 	"github.com/acorn-io/mink/pkg/serializer"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,6 +68,7 @@ func APIStores(c kclient.WithWatch, cfg, localCfg *clientgo.Config) (map[string]
 		"apps/log":               logsStorage,
 		"apps/confirmupgrade":    apps.NewConfirmUpgrade(c),
 		"apps/pullimage":         apps.NewPullAppImage(c),
+		"apps/jobrun":            apps.NewJobRun(c),
 		"builders":               buildersStorage,
 		"builders/port":          buildersPort,
 		"images":                 imagesStorage,
@@ -76,6 +78,8 @@ func APIStores(c kclient.WithWatch, cfg, localCfg *clientgo.Config) (map[string]
 		"images/details":         images.NewImageDetails(c, transport),
 		"projects":               projects.NewStorage(c),
 		"volumes":                volumesStorage,
+		"volumebackups":          volumebackups.NewStorage(c),
+		"volumebackups/restore":  volumebackups.NewRestore(c),
 		"containerreplicas":      containersStorage,
 		"containerreplicas/exec": containerExec,
 		"credentials":            credentials.NewStore(c),
@@ -4,13 +4,16 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/secretprovider"
 	"github.com/acorn-io/mink/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	ktypes "k8s.io/apimachinery/pkg/types"
@@ -45,7 +48,7 @@ func (t *Translator) FromPublicName(ctx context.Context, namespace, name string)
 			continue
 		}
 		for _, binding := range app.Spec.Secrets {
-			if binding.Target == secretName {
+			if binding.Target == secretName && !secretprovider.IsReference(binding.Secret) {
 				return namespace, binding.Secret, nil
 			}
 		}
@@ -89,6 +92,7 @@ func (t *Translator) ToPublic(objs ...runtime.Object) (result []types.Object) {
 			ObjectMeta: secret.ObjectMeta,
 			Type:       strings.TrimPrefix(string(secret.Type), v1.SecretTypePrefix),
 			Keys:       keys,
+			Rotations:  rotations(secret),
 		}
 		sec.UID = sec.UID + "-s"
 		if t.reveal {
@@ -141,3 +145,18 @@ func (t *Translator) NewPublicList() types.ObjectList {
 func ignore(secret *corev1.Secret) bool {
 	return !strings.HasPrefix(string(secret.Type), "secrets.acorn.io/")
 }
+
+// rotations returns the times the values of a generated secret were generated, oldest first
+func rotations(secret *corev1.Secret) (result []metav1.Time) {
+	value := secret.Annotations[labels.AcornSecretRotations]
+	if value == "" {
+		return nil
+	}
+	for _, rotatedAt := range strings.Split(value, ",") {
+		t, err := time.Parse(time.RFC3339, rotatedAt)
+		if err == nil {
+			result = append(result, metav1.NewTime(t))
+		}
+	}
+	return
+}
@@ -0,0 +1,199 @@
+package volumebackups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/volumebackup"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	name2 "github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restoreURLExpiry is how long the Job has to start downloading the archive
+const restoreURLExpiry = 24 * time.Hour
+
+func NewRestore(c kclient.WithWatch) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.VolumeBackupRestore{}).
+		WithCreate(&RestoreStrategy{
+			client: c,
+		}).Build()
+}
+
+type RestoreStrategy struct {
+	client kclient.WithWatch
+}
+
+func (s *RestoreStrategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	restore := obj.(*apiv1.VolumeBackupRestore)
+	ri, _ := request.RequestInfoFrom(ctx)
+
+	backup := &v1.VolumeBackupInstance{}
+	if err := s.client.Get(ctx, router.Key(ri.Namespace, ri.Name), backup); err != nil {
+		return nil, err
+	}
+	if !backup.Status.Ready {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("backup [%s] is not ready", backup.Name))
+	}
+
+	pv, err := getVolume(ctx, s.client, backup.Namespace, restore.Volume)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	// The contents of the volume are replaced, which must not happen underneath a running app
+	pods, err := volumebackup.ClaimPods(ctx, s.client, pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) > 0 {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("volume [%s] is in use by pod [%s], stop app [%s] before restoring",
+			restore.Volume, pods[0].Name, pv.Labels[labels.AcornAppName]))
+	}
+
+	var url, sourceClaim string
+	switch backup.Status.Method {
+	case v1.VolumeBackupMethodS3:
+		target, err := volumebackup.GetTarget(ctx, s.client)
+		if err != nil {
+			return nil, err
+		}
+		url = target.Presign(http.MethodGet, target.Key(backup.Namespace, backup.Name), time.Now(), restoreURLExpiry)
+	case v1.VolumeBackupMethodSnapshot:
+		sourceClaim, err = s.restoreSnapshot(ctx, backup, pv)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("backup [%s] has unknown method [%s]", backup.Name, backup.Status.Method))
+	}
+
+	job := volumebackup.RestoreJob(backup, pv, url, sourceClaim)
+	if sourceClaim != "" {
+		// The claim is named after the Job so that both can be found together
+		job.GenerateName = ""
+		job.Name = sourceClaim
+	}
+	if err := s.client.Create(ctx, job); err != nil {
+		if sourceClaim != "" {
+			s.deleteClaim(ctx, job.Namespace, sourceClaim)
+		}
+		return nil, err
+	}
+
+	if sourceClaim != "" {
+		if err := s.ownClaim(ctx, job.Namespace, sourceClaim, job.Name, job.UID); err != nil {
+			// Without the owner the claim would outlive the Job, so both are removed
+			_ = s.client.Delete(ctx, job, kclient.PropagationPolicy(metav1.DeletePropagationBackground))
+			s.deleteClaim(ctx, job.Namespace, sourceClaim)
+			return nil, err
+		}
+	}
+
+	restore.AppName = pv.Labels[labels.AcornAppName]
+	restore.Job = job.Name
+	return restore, nil
+}
+
+// restoreSnapshot creates a claim from the snapshot of the backup. Snapshots can only be restored in their own
+// namespace, so the volume must belong to the same app the backup was taken from.
+func (s *RestoreStrategy) restoreSnapshot(ctx context.Context, backup *v1.VolumeBackupInstance, pv *corev1.PersistentVolume) (string, error) {
+	if pv.Spec.ClaimRef.Namespace != backup.Status.Namespace {
+		return "", apierrors.NewBadRequest(fmt.Sprintf("backup [%s] is a snapshot and can only be restored to a volume of app [%s]",
+			backup.Name, backup.Status.AppName))
+	}
+
+	snapshot := volumebackup.NewSnapshot()
+	if err := s.client.Get(ctx, router.Key(backup.Status.Namespace, volumebackup.SnapshotName(backup)), snapshot); err != nil {
+		return "", err
+	}
+
+	size := pv.Spec.Capacity[corev1.ResourceStorage]
+	if _, restoreSize, _ := volumebackup.SnapshotStatus(snapshot); restoreSize != "" {
+		q, err := resource.ParseQuantity(restoreSize)
+		if err != nil {
+			return "", err
+		}
+		size = q
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name2.SafeConcatName("acorn-restore", backup.Name) + "-",
+			Namespace:    backup.Status.Namespace,
+			Labels: map[string]string{
+				labels.AcornVolumeBackupName: backup.Name,
+				labels.AcornVolumeRestore:    "true",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &volumebackup.SnapshotGVK.Group,
+				Kind:     volumebackup.SnapshotGVK.Kind,
+				Name:     snapshot.GetName(),
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if pv.Spec.StorageClassName != "" {
+		claim.Spec.StorageClassName = &pv.Spec.StorageClassName
+	}
+
+	if err := s.client.Create(ctx, claim); err != nil {
+		return "", err
+	}
+	return claim.Name, nil
+}
+
+// ownClaim makes the Job the owner of the claim so that the claim is removed with the Job
+func (s *RestoreStrategy) ownClaim(ctx context.Context, namespace, name, jobName string, jobUID ktypes.UID) error {
+	claim := &corev1.PersistentVolumeClaim{}
+	if err := s.client.Get(ctx, router.Key(namespace, name), claim); err != nil {
+		return err
+	}
+	claim.OwnerReferences = append(claim.OwnerReferences, metav1.OwnerReference{
+		APIVersion: "batch/v1",
+		Kind:       "Job",
+		Name:       jobName,
+		UID:        jobUID,
+	})
+	return s.client.Update(ctx, claim)
+}
+
+// deleteClaim removes the claim restored from a snapshot when the restore could not be started. The request already
+// failed, so an error removing the claim is only logged.
+func (s *RestoreStrategy) deleteClaim(ctx context.Context, namespace, name string) {
+	err := s.client.Delete(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		logrus.Errorf("failed to delete restore claim %s/%s: %v", namespace, name, err)
+	}
+}
+
+func (s *RestoreStrategy) New() types.Object {
+	return &apiv1.VolumeBackupRestore{}
+}
@@ -0,0 +1,18 @@
+package volumebackups
+
+import (
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	mtypes "github.com/acorn-io/mink/pkg/types"
+)
+
+type Translator struct {
+}
+
+func (s *Translator) FromPublic(obj mtypes.Object) mtypes.Object {
+	return (*v1.VolumeBackupInstance)(obj.(*apiv1.VolumeBackup))
+}
+
+func (s *Translator) ToPublic(obj mtypes.Object) mtypes.Object {
+	return (*apiv1.VolumeBackup)(obj.(*v1.VolumeBackupInstance))
+}
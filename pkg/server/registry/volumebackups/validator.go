@@ -0,0 +1,59 @@
+package volumebackups
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Validator struct {
+	client kclient.Client
+}
+
+func (v *Validator) Validate(ctx context.Context, obj runtime.Object) (result field.ErrorList) {
+	backup := obj.(*apiv1.VolumeBackup)
+	volumePath := field.NewPath("spec", "volume")
+
+	if backup.Spec.Volume == "" {
+		return append(result, field.Required(volumePath, "the volume to back up must be set"))
+	}
+
+	if _, err := getVolume(ctx, v.client, backup.Namespace, backup.Spec.Volume); err != nil {
+		return append(result, field.Invalid(volumePath, backup.Spec.Volume, err.Error()))
+	}
+
+	cfg, err := config.Get(ctx, v.client)
+	if err != nil {
+		return append(result, field.InternalError(volumePath, err))
+	}
+	if (cfg.VolumeSnapshotClass == nil || *cfg.VolumeSnapshotClass == "") && (cfg.VolumeBackupURL == nil || *cfg.VolumeBackupURL == "") {
+		result = append(result, field.Invalid(volumePath, backup.Spec.Volume,
+			"volume backups are not configured, set --volume-snapshot-class or --volume-backup-url with acorn install"))
+	}
+	return
+}
+
+// getVolume returns the PersistentVolume of a volume in the project, the volume must be bound to an app
+func getVolume(ctx context.Context, c kclient.Client, namespace, name string) (*corev1.PersistentVolume, error) {
+	pv := &corev1.PersistentVolume{}
+	err := c.Get(ctx, router.Key("", name), pv)
+	if apierrors.IsNotFound(err) || err == nil && pv.Labels[labels.AcornAppNamespace] != namespace {
+		return nil, fmt.Errorf("volume [%s] not found", name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if pv.Spec.ClaimRef == nil || pv.Status.Phase != corev1.VolumeBound {
+		return nil, fmt.Errorf("volume [%s] is not bound to an app", name)
+	}
+	return pv, nil
+}
@@ -78,6 +78,9 @@ func pvToVolume(pv corev1.PersistentVolume) *apiv1.Volume {
 			},
 		},
 	}
+	if replica := pv.Labels[labels.AcornContainerReplica]; replica != "" {
+		vol.Status.ContainerReplicaName = vol.Status.AppName + "." + replica
+	}
 	vol.UID = vol.UID + "-v"
 	vol.Namespace = pv.Labels[labels.AcornAppNamespace]
 	if !pv.DeletionTimestamp.IsZero() {
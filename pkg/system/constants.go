@@ -3,13 +3,18 @@ package system
 import "os"
 
 const (
-	Namespace            = "acorn-system"
-	ImagesNamespace      = "acorn-image-system"
-	ConfigName           = "acorn-config"
-	TLSSecretName        = "acorn-tls"
-	LEAccountSecretName  = "acorn-le-account"
-	DefaultUserNamespace = "acorn"
-	DNSSecretName        = "acorn-dns"
+	Namespace              = "acorn-system"
+	ImagesNamespace        = "acorn-image-system"
+	ConfigName             = "acorn-config"
+	TLSSecretName          = "acorn-tls"
+	LEAccountSecretName    = "acorn-le-account"
+	DefaultUserNamespace   = "acorn"
+	DNSSecretName          = "acorn-dns"
+	VolumeBackupSecretName = "acorn-volume-backup"
+	// VolumeExecMountPath is where the volume is mounted in the pod created to run a command against a volume
+	VolumeExecMountPath = "/volume"
+	// VolumeSeedContextPath is where the build context directory of a volume seed is copied in the seed image
+	VolumeSeedContextPath = "/acorn-seed"
 )
 
 var (
@@ -14,6 +14,7 @@ var (
 		{"Up-To-Date", "Status.Columns.UpToDate"},
 		{"Created", "{{ago .CreationTimestamp}}"},
 		{"Endpoints", "Status.Columns.Endpoints"},
+		{"Resources", "Status.Columns.Resources"},
 		{"Message", "{{ appGeneration . .Status.Columns.Message }}"},
 	}
 	AppConverter = MustConverter(App)
@@ -29,6 +30,17 @@ var (
 	}
 	VolumeConverter = MustConverter(Volume)
 
+	VolumeBackup = [][]string{
+		{"Name", "{{ . | name }}"},
+		{"Volume", "Spec.Volume"},
+		{"App-Name", "Status.AppName"},
+		{"Bound-Volume", "Status.VolumeName"},
+		{"Method", "Status.Method"},
+		{"Ready", "{{boolToStar .Status.Ready}}"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+	VolumeBackupConverter = MustConverter(VolumeBackup)
+
 	//Used for acorn image related printing
 	ImageAcorn = [][]string{
 		{"Repository", "{{if eq .Repository \"\"}}<none>{{else}}{{.Repository}}{{end}}"},
@@ -123,4 +135,9 @@ var (
 		{"Resource", "Resource"},
 		{"Scope", "Scope"},
 	}
+
+	SecurityRequests = [][]string{
+		{"Service", "Service"},
+		{"Escalation", "Escalation"},
+	}
 )
@@ -0,0 +1,217 @@
+package volumebackup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Archive writes the contents of dir as a gzip compressed tar to w. Regular files, directories and symlinks are
+// archived, everything else is skipped.
+func Archive(dir string, w io.Writer) error {
+	return archive(dir, w, nil)
+}
+
+// archive is Archive that leaves out the paths skip returns true for, including everything below them
+func archive(dir string, w io.Writer, skip func(path string) bool) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if skip != nil && skip(path) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var link string
+		switch {
+		case info.Mode().IsRegular(), info.IsDir():
+		case info.Mode()&os.ModeSymlink != 0:
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Extract replaces the contents of dir with the archive read from r. Symlinks are created last so that no file is
+// written through a symlink of the archive, and the attributes of directories are set once their content exists.
+func Extract(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	if err := removeContents(dir); err != nil {
+		return err
+	}
+
+	return extract(gz, dir)
+}
+
+// extract writes the uncompressed tar read from r to dir
+func extract(r io.Reader, dir string) error {
+	var dirs, symlinks []*tar.Header
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		// Cleaning the name as an absolute path keeps it inside dir
+		target := filepath.Join(dir, filepath.Clean("/"+hdr.Name))
+		if target == dir {
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			dirs = append(dirs, hdr)
+		case tar.TypeReg:
+			if err := extractFile(tr, hdr, target); err != nil {
+				return err
+			}
+			setAttributes(hdr, target)
+		case tar.TypeSymlink:
+			symlinks = append(symlinks, hdr)
+		}
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, hdr := range symlinks {
+		target := filepath.Join(dir, filepath.Clean("/"+hdr.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		// The parent could be one of the symlinks that was just created
+		if parent, err := filepath.EvalSymlinks(filepath.Dir(target)); err != nil {
+			return err
+		} else if parent != root && !strings.HasPrefix(parent, root+string(filepath.Separator)) {
+			return fmt.Errorf("invalid symlink %s, the parent is outside of the archive", hdr.Name)
+		}
+		if err := os.Symlink(hdr.Linkname, target); err != nil {
+			return err
+		}
+		_ = os.Lchown(target, hdr.Uid, hdr.Gid)
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		setAttributes(dirs[i], filepath.Join(dir, filepath.Clean("/"+dirs[i].Name)))
+	}
+
+	return nil
+}
+
+// CopyDir replaces the contents of dir with the contents of src
+func CopyDir(src, dir string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(Archive(src, pw))
+	}()
+	err := Extract(pr, dir)
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+func extractFile(r io.Reader, hdr *tar.Header, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", hdr.Name, err)
+	}
+	return f.Close()
+}
+
+// setAttributes restores the owner, mode and modification time. Changing the owner fails if not running as root,
+// which is ignored.
+func setAttributes(hdr *tar.Header, target string) {
+	_ = os.Lchown(target, hdr.Uid, hdr.Gid)
+	_ = os.Chmod(target, hdr.FileInfo().Mode().Perm()|hdr.FileInfo().Mode()&(os.ModeSetuid|os.ModeSetgid|os.ModeSticky))
+	_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+}
+
+func removeContents(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package volumebackup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveExtract(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "a", "b"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a", "b", "file"), []byte("data"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "top"), []byte("top"), 0755))
+	require.NoError(t, os.Symlink("a/b/file", filepath.Join(src, "link")))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, Archive(src, buf))
+
+	dst := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dst, "stale"), []byte("stale"), 0644))
+	require.NoError(t, Extract(buf, dst))
+
+	_, err := os.Stat(filepath.Join(dst, "stale"))
+	assert.True(t, os.IsNotExist(err), "existing contents must be removed")
+
+	data, err := os.ReadFile(filepath.Join(dst, "a", "b", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	info, err := os.Stat(filepath.Join(dst, "top"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(dst, "a"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dst, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "a/b/file", link)
+}
+
+func TestExtractOutsideDir(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape", Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+	_, err := tw.Write([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	parent := t.TempDir()
+	dst := filepath.Join(parent, "data")
+	require.NoError(t, os.Mkdir(dst, 0755))
+	require.NoError(t, Extract(buf, dst))
+
+	_, err = os.Lstat(filepath.Join(parent, "escape"))
+	assert.True(t, os.IsNotExist(err), "file must not be written outside of the directory")
+
+	data, err := os.ReadFile(filepath.Join(dst, "escape"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+}
@@ -0,0 +1,226 @@
+package volumebackup
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/system"
+	name2 "github.com/rancher/wrangler/pkg/name"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DataPath is where the volume is mounted in the helper pod
+	DataPath = "/data"
+	// SourcePath is where the volume restored from a snapshot is mounted in the helper pod
+	SourcePath = "/source"
+	// UploadPath is where the Secret with the presigned upload requests is mounted in the helper pod
+	UploadPath = "/upload"
+
+	uploadKey = "upload.json"
+
+	// restoreTTLSeconds is how long a finished restore is kept around so its logs can still be read
+	restoreTTLSeconds = 60 * 60
+	backoffLimit      = 2
+)
+
+// BackupJobName returns the name of the Job that uploads the backup
+func BackupJobName(backup *v1.VolumeBackupInstance) string {
+	return name2.SafeConcatName("acorn-backup", backup.Name)
+}
+
+// BackupJob returns the Job that archives the volume of the backup and uploads it with the requests of the
+// BackupSecret. The pod runs on nodeName, if set, so that a ReadWriteOnce volume that is in use can be mounted.
+func BackupJob(backup *v1.VolumeBackupInstance, nodeName string) *batchv1.Job {
+	job := helperJob(backup.Status.Namespace, nodeName, backupLabels(backup), []string{"upload", path.Join(UploadPath, uploadKey), DataPath})
+	job.Name = BackupJobName(backup)
+	addClaim(&job.Spec.Template.Spec, "data", backup.Status.ClaimName, DataPath, true)
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "upload",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: BackupJobName(backup),
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "upload",
+		MountPath: UploadPath,
+		ReadOnly:  true,
+	})
+	return job
+}
+
+// BackupSecret returns the Secret with the presigned requests the Job of the backup uploads the archive with
+func BackupSecret(backup *v1.VolumeBackupInstance, upload *MultipartUpload) (*corev1.Secret, error) {
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BackupJobName(backup),
+			Namespace: backup.Status.Namespace,
+			Labels:    labels.Merge(backupLabels(backup), map[string]string{labels.AcornManaged: "true"}),
+		},
+		Data: map[string][]byte{
+			uploadKey: data,
+		},
+	}, nil
+}
+
+func backupLabels(backup *v1.VolumeBackupInstance) map[string]string {
+	return map[string]string{
+		labels.AcornAppName:          backup.Status.AppName,
+		labels.AcornAppNamespace:     backup.Namespace,
+		labels.AcornVolumeBackupName: backup.Name,
+	}
+}
+
+// RestoreJob returns the Job that replaces the contents of the claim. If url is set the archive is downloaded from
+// it, otherwise the data is copied from the claim restored from a snapshot.
+func RestoreJob(backup *v1.VolumeBackupInstance, pv *corev1.PersistentVolume, url, sourceClaim string) *batchv1.Job {
+	args := []string{"download", url, DataPath}
+	if url == "" {
+		args = []string{"copy", SourcePath, DataPath}
+	}
+
+	job := helperJob(pv.Spec.ClaimRef.Namespace, "", map[string]string{
+		labels.AcornAppName:          pv.Labels[labels.AcornAppName],
+		labels.AcornAppNamespace:     pv.Labels[labels.AcornAppNamespace],
+		labels.AcornVolumeBackupName: backup.Name,
+		labels.AcornVolumeRestore:    "true",
+	}, args)
+	job.GenerateName = name2.SafeConcatName("acorn-restore", backup.Name) + "-"
+	job.Spec.TTLSecondsAfterFinished = &[]int32{restoreTTLSeconds}[0]
+
+	addClaim(&job.Spec.Template.Spec, "data", pv.Spec.ClaimRef.Name, DataPath, false)
+	if sourceClaim != "" {
+		addClaim(&job.Spec.Template.Spec, "source", sourceClaim, SourcePath, true)
+	}
+	return job
+}
+
+func helperJob(namespace, nodeName string, labelMap map[string]string, args []string) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels:    labels.Merge(labelMap, map[string]string{labels.AcornManaged: "true"}),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &[]int32{backoffLimit}[0],
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels.Merge(labelMap, map[string]string{labels.AcornManaged: "true"}),
+				},
+				Spec: corev1.PodSpec{
+					NodeName:           nodeName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					EnableServiceLinks: new(bool),
+					Containers: []corev1.Container{
+						{
+							Name:            "volume-helper",
+							Image:           system.DefaultImage(),
+							Args:            append([]string{"volume-helper"}, args...),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "tmp",
+									MountPath: "/tmp",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "tmp",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func addClaim(podSpec *corev1.PodSpec, name, claimName, mountPath string, readOnly bool) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+				ReadOnly:  readOnly,
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      name,
+		MountPath: mountPath,
+		ReadOnly:  readOnly,
+	})
+}
+
+// ClaimPods returns the pods that mount the claim and have not terminated
+func ClaimPods(ctx context.Context, c kclient.Reader, namespace, claimName string) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, &kclient.ListOptions{
+		Namespace: namespace,
+	}); err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+				result = append(result, pod)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ClaimNodeName returns the node of a pod that mounts the claim, if there is one. A pod that is still starting, for
+// example while its init containers seed the volume, already has the volume attached to its node.
+func ClaimNodeName(ctx context.Context, c kclient.Reader, namespace, claimName string) (string, error) {
+	pods, err := ClaimPods(ctx, c, namespace, claimName)
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			return pod.Spec.NodeName, nil
+		}
+	}
+	return "", nil
+}
+
+// IsJobFinished returns true and the failure message if the job failed after all retries
+func IsJobFinished(job *batchv1.Job) (bool, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, ""
+		case batchv1.JobFailed:
+			return true, cond.Message
+		}
+	}
+	return false, ""
+}
@@ -0,0 +1,54 @@
+package volumebackup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClaimNodeName(t *testing.T) {
+	pod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "app-ns",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Volumes: []corev1.Volume{
+					{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "data",
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		pod("finished", "node-1", corev1.PodSucceeded),
+		pod("unscheduled", "", corev1.PodPending),
+	).Build()
+	nodeName, err := ClaimNodeName(context.Background(), c, "app-ns", "data")
+	require.NoError(t, err)
+	assert.Equal(t, "", nodeName)
+
+	// A pod that is still starting already has the volume attached
+	require.NoError(t, c.Create(context.Background(), pod("starting", "node-2", corev1.PodPending)))
+	nodeName, err = ClaimNodeName(context.Background(), c, "app-ns", "data")
+	require.NoError(t, err)
+	assert.Equal(t, "node-2", nodeName)
+}
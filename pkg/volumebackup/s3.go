@@ -0,0 +1,321 @@
+package volumebackup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultRegion = "us-east-1"
+	// MaxPresignExpiry is the longest time a presigned URL can be valid for
+	MaxPresignExpiry = 7 * 24 * time.Hour
+
+	// S3 requires every part but the last to be at least 5MiB and allows parts of up to 5GiB. Fewer parts than the
+	// 10000 S3 allows are presigned to keep the list of URLs small enough for a Secret.
+	minPartSize = 16 << 20
+	maxPartSize = 5 << 30
+	maxParts    = 1000
+
+	// requestTimeout is how long the controller waits for a response of the object store
+	requestTimeout = 30 * time.Second
+)
+
+var (
+	awsHostRegexp = regexp.MustCompile(`^s3[.-]([a-z0-9-]+)\.amazonaws\.com$`)
+	httpClient    = &http.Client{
+		Timeout: requestTimeout,
+	}
+)
+
+// Target is an S3 compatible bucket that backups are uploaded to. Objects are addressed in path style as
+// ENDPOINT/BUCKET/KEY so that any S3 compatible store works.
+type Target struct {
+	Endpoint        url.URL
+	Bucket          string
+	Prefix          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// ParseTarget parses a location in the form http(s)://HOST/BUCKET/PREFIX
+func ParseTarget(location string) (*Target, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume backup URL [%s]: %w", location, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid volume backup URL [%s], must be http or https", location)
+	}
+
+	bucket, prefix, _ := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid volume backup URL [%s], the bucket must be set as the first part of the path", location)
+	}
+
+	region := defaultRegion
+	if m := awsHostRegexp.FindStringSubmatch(u.Hostname()); m != nil {
+		region = m[1]
+	}
+
+	return &Target{
+		Endpoint: url.URL{
+			Scheme: u.Scheme,
+			Host:   u.Host,
+		},
+		Bucket: bucket,
+		Prefix: prefix,
+		Region: region,
+	}, nil
+}
+
+// GetTarget returns the configured target with the credentials from the acorn-volume-backup secret. The secret must
+// have the keys accessKeyID and secretAccessKey, and can override the region with the key region.
+func GetTarget(ctx context.Context, c kclient.Reader) (*Target, error) {
+	cfg, err := config.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.VolumeBackupURL == nil || *cfg.VolumeBackupURL == "" {
+		return nil, fmt.Errorf("volume backups to S3 are not configured, set --volume-backup-url with acorn install")
+	}
+
+	target, err := ParseTarget(*cfg.VolumeBackupURL)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = c.Get(ctx, router.Key(system.Namespace, system.VolumeBackupSecretName), secret)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("missing secret %s/%s with the credentials for volume backups", system.Namespace, system.VolumeBackupSecretName)
+	} else if err != nil {
+		return nil, err
+	}
+
+	target.AccessKeyID = string(secret.Data["accessKeyID"])
+	target.SecretAccessKey = string(secret.Data["secretAccessKey"])
+	if region := string(secret.Data["region"]); region != "" {
+		target.Region = region
+	}
+	return target, nil
+}
+
+// Key returns the object key of a backup
+func (t *Target) Key(namespace, name string) string {
+	return path.Join(t.Prefix, namespace, name+".tar.gz")
+}
+
+// URL returns the unsigned URL of an object
+func (t *Target) URL(key string) *url.URL {
+	u := t.Endpoint
+	u.Path = "/" + t.Bucket + "/" + key
+	u.RawPath = "/" + uriEncode(t.Bucket, false) + "/" + uriEncode(key, false)
+	return &u
+}
+
+// Presign returns a URL for the object that can be used with the method without credentials until date+expires
+func (t *Target) Presign(method, key string, date time.Time, expires time.Duration) string {
+	return t.presign(method, key, nil, date, expires)
+}
+
+func (t *Target) presign(method, key string, params map[string]string, date time.Time, expires time.Duration) string {
+	return presign(method, t.URL(key), params, t.Region, t.AccessKeyID, t.SecretAccessKey, date, expires)
+}
+
+// Delete removes an object, a missing object is not an error
+func (t *Target) Delete(ctx context.Context, key string) error {
+	resp, err := t.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("deleting %s: unexpected status %s", key, resp.Status)
+	}
+}
+
+// CreateMultipartUpload starts an upload of the object in parts and returns its ID
+func (t *Target) CreateMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := t.do(ctx, http.MethodPost, key, map[string]string{"uploads": ""})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("creating upload of %s: unexpected status %s", key, resp.Status)
+	}
+
+	result := struct {
+		UploadID string `xml:"UploadId"`
+	}{}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("creating upload of %s: %w", key, err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("creating upload of %s: missing upload ID", key)
+	}
+	return result.UploadID, nil
+}
+
+// AbortMultipartUpload removes the parts of an upload that was not completed, a missing upload is not an error
+func (t *Target) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	resp, err := t.do(ctx, http.MethodDelete, key, map[string]string{"uploadId": uploadID})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("aborting upload of %s: unexpected status %s", key, resp.Status)
+	}
+}
+
+// MultipartUpload presigns the requests that upload an archive of up to maxSize bytes to the upload until
+// date+MaxPresignExpiry. The parts are sized so that at most maxParts are needed.
+func (t *Target) MultipartUpload(key, uploadID string, maxSize int64, date time.Time) (*MultipartUpload, error) {
+	partSize := (maxSize + maxParts - 1) / maxParts
+	if partSize < minPartSize {
+		partSize = minPartSize
+	} else if partSize > maxPartSize {
+		return nil, fmt.Errorf("%d bytes can not be uploaded in %d parts of at most %d bytes", maxSize, maxParts, maxPartSize)
+	}
+
+	upload := &MultipartUpload{
+		PartSize: partSize,
+		Complete: t.presign(http.MethodPost, key, map[string]string{"uploadId": uploadID}, date, MaxPresignExpiry),
+	}
+	parts := (maxSize + partSize - 1) / partSize
+	if parts == 0 {
+		parts = 1
+	}
+	for i := int64(1); i <= parts; i++ {
+		upload.Parts = append(upload.Parts, t.presign(http.MethodPut, key, map[string]string{
+			"partNumber": strconv.FormatInt(i, 10),
+			"uploadId":   uploadID,
+		}, date, MaxPresignExpiry))
+	}
+	return upload, nil
+}
+
+// do sends a request for the object signed with the credentials of the target
+func (t *Target) do(ctx context.Context, method, key string, params map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.presign(method, key, params, time.Now(), time.Hour), nil)
+	if err != nil {
+		return nil, err
+	}
+	return httpClient.Do(req)
+}
+
+// presign signs the URL with AWS signature version 4 in the query string. The params are added to the query and
+// signed with it.
+func presign(method string, u *url.URL, params map[string]string, region, accessKeyID, secretAccessKey string, date time.Time, expires time.Duration) string {
+	var (
+		amzDate   = date.UTC().Format("20060102T150405Z")
+		day       = amzDate[:8]
+		scope     = day + "/" + region + "/s3/aws4_request"
+		canonical = url.URL{
+			Scheme:  u.Scheme,
+			Host:    u.Host,
+			Path:    u.Path,
+			RawPath: u.EscapedPath(),
+		}
+	)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    accessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expires.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	for k, v := range params {
+		query[k] = v
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var encoded []string
+	for _, k := range keys {
+		encoded = append(encoded, uriEncode(k, true)+"="+uriEncode(query[k], true))
+	}
+	canonicalQuery := strings.Join(encoded, "&")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonical.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	canonical.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return canonical.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// uriEncode escapes everything but the unreserved characters of RFC 3986, as required for signing
+func uriEncode(s string, encodeSlash bool) string {
+	buf := strings.Builder{}
+	for _, b := range []byte(s) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9', b == '-', b == '.', b == '_', b == '~':
+			buf.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			buf.WriteByte(b)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
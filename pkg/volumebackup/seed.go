@@ -0,0 +1,86 @@
+package volumebackup
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// SeedBinPath is where the acorn binary is available to the init container that seeds a volume
+	SeedBinPath = "/.acorn-seed"
+	// SeedDataPath is where the volume is mounted in the init container that seeds it
+	SeedDataPath = "/.acorn-seed-volume"
+)
+
+// virtualDirs are never copied when the root of a seed image is used
+var virtualDirs = []string{"/dev", "/proc", "/sys"}
+
+// Seed copies the contents of src into dir if dir is empty. A dir that has content is not changed, so data of a
+// volume that is bound to an app is kept. Whether a volume was seeded is tracked by the controller, nothing but the
+// seed is written to dir. If the copy fails dir is emptied again so that the next attempt starts over.
+func Seed(src, dir string) error {
+	empty, err := isEmpty(dir)
+	if err != nil || !empty {
+		return err
+	}
+
+	skip := append([]string{dir, SeedBinPath}, virtualDirs...)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(archive(src, pw, func(path string) bool {
+			for _, s := range skip {
+				if path == s {
+					return true
+				}
+			}
+			return false
+		}))
+	}()
+
+	gz, err := gzip.NewReader(pr)
+	if err == nil {
+		err = extract(gz, dir)
+	}
+	_ = pr.CloseWithError(err)
+	if err != nil {
+		if removeErr := removeSeed(dir); removeErr != nil {
+			return fmt.Errorf("%w, emptying the volume again failed: %v", err, removeErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// isEmpty returns true if dir has no other content than lost+found
+func isEmpty(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.Name() != "lost+found" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// removeSeed removes everything but lost+found from dir
+func removeSeed(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() == "lost+found" {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
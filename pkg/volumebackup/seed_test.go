@@ -0,0 +1,71 @@
+package volumebackup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeed(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "a"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a", "file"), []byte("seed"), 0644))
+
+	dst := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dst, "lost+found"), 0700))
+	require.NoError(t, Seed(src, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "a", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "seed", string(data))
+	assert.Equal(t, []string{"a", "lost+found"}, dirNames(t, dst))
+
+	// A volume with content is never changed again
+	require.NoError(t, os.WriteFile(filepath.Join(dst, "a", "file"), []byte("changed"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(src, "a", "file")))
+	require.NoError(t, Seed(src, dst))
+
+	data, err = os.ReadFile(filepath.Join(dst, "a", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(data))
+}
+
+func TestSeedExistingData(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "file"), []byte("seed"), 0644))
+
+	dst := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dst, "existing"), []byte("data"), 0644))
+	require.NoError(t, Seed(src, dst))
+
+	_, err := os.Stat(filepath.Join(dst, "file"))
+	assert.True(t, os.IsNotExist(err), "a volume with data must not be seeded")
+	assert.Equal(t, []string{"existing"}, dirNames(t, dst))
+}
+
+func TestSeedSkipsVolume(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "file"), []byte("seed"), 0644))
+
+	// The volume being seeded is mounted inside the seed when the whole image is copied
+	dst := filepath.Join(src, "volume")
+	require.NoError(t, os.Mkdir(dst, 0755))
+	require.NoError(t, Seed(src, dst))
+
+	_, err := os.Stat(filepath.Join(dst, "file"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dst, "volume"))
+	assert.True(t, os.IsNotExist(err), "the volume must not be copied into itself")
+}
+
+func dirNames(t *testing.T, dir string) (result []string) {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		result = append(result, entry.Name())
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package volumebackup
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	name2 "github.com/rancher/wrangler/pkg/name"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshots are handled as unstructured objects so that the snapshot CRDs are only required if snapshots are
+// used
+var SnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// SnapshotName returns the name of the VolumeSnapshot of the backup
+func SnapshotName(backup *v1.VolumeBackupInstance) string {
+	return name2.SafeConcatName("acorn-backup", backup.Name)
+}
+
+// NewSnapshot returns an empty VolumeSnapshot that can be used to get an existing snapshot
+func NewSnapshot() *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(SnapshotGVK)
+	return snapshot
+}
+
+// Snapshot returns the VolumeSnapshot of the claim the backup is taken from
+func Snapshot(backup *v1.VolumeBackupInstance, class string) *unstructured.Unstructured {
+	snapshot := NewSnapshot()
+	snapshot.SetName(SnapshotName(backup))
+	snapshot.SetNamespace(backup.Status.Namespace)
+	snapshot.SetLabels(map[string]string{
+		labels.AcornManaged:          "true",
+		labels.AcornAppName:          backup.Status.AppName,
+		labels.AcornAppNamespace:     backup.Namespace,
+		labels.AcornVolumeBackupName: backup.Name,
+	})
+	snapshot.Object["spec"] = map[string]any{
+		"volumeSnapshotClassName": class,
+		"source": map[string]any{
+			"persistentVolumeClaimName": backup.Status.ClaimName,
+		},
+	}
+	return snapshot
+}
+
+// SnapshotStatus returns if the snapshot can be used, the size of a volume restored from it and the error reported
+// by the snapshot controller
+func SnapshotStatus(snapshot *unstructured.Unstructured) (ready bool, restoreSize string, errMessage string) {
+	ready, _, _ = unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	restoreSize, _, _ = unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+	errMessage, _, _ = unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return
+}
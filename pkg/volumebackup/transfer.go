@@ -0,0 +1,179 @@
+package volumebackup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// MultipartUpload are the presigned requests that upload an archive in parts. Part N of the archive is uploaded to
+// Parts[N-1] and the upload is finished with a POST to Complete.
+type MultipartUpload struct {
+	PartSize int64    `json:"partSize"`
+	Parts    []string `json:"parts"`
+	Complete string   `json:"complete"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// Upload archives dir and uploads it in parts with the presigned requests read as JSON from the file uploadFile. The
+// archive is written to a temporary file first because S3 requires the length of every part up front.
+func Upload(ctx context.Context, uploadFile, dir string) error {
+	data, err := os.ReadFile(uploadFile)
+	if err != nil {
+		return err
+	}
+	upload := &MultipartUpload{}
+	if err := json.Unmarshal(data, upload); err != nil {
+		return fmt.Errorf("reading %s: %w", uploadFile, err)
+	}
+	if upload.PartSize <= 0 {
+		return fmt.Errorf("reading %s: invalid part size %d", uploadFile, upload.PartSize)
+	}
+
+	f, err := os.CreateTemp("", "backup-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := Archive(dir, f); err != nil {
+		return fmt.Errorf("archiving %s: %w", dir, err)
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	parts := int((size + upload.PartSize - 1) / upload.PartSize)
+	if parts == 0 {
+		parts = 1
+	}
+	if parts > len(upload.Parts) {
+		return fmt.Errorf("archive of %d bytes needs %d parts, only %d can be uploaded", size, parts, len(upload.Parts))
+	}
+
+	var completed []completedPart
+	for i := 0; i < parts; i++ {
+		offset := int64(i) * upload.PartSize
+		length := upload.PartSize
+		if offset+length > size {
+			length = size - offset
+		}
+		etag, err := uploadPart(ctx, upload.Parts[i], io.NewSectionReader(f, offset, length), length)
+		if err != nil {
+			return fmt.Errorf("uploading part %d of backup: %w", i+1, err)
+		}
+		completed = append(completed, completedPart{
+			PartNumber: i + 1,
+			ETag:       etag,
+		})
+	}
+
+	return completeUpload(ctx, upload.Complete, completed)
+}
+
+func uploadPart(ctx context.Context, url string, body io.Reader, length int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = length
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// completeUpload assembles the object from the parts. S3 can report a failure with a 200 status once it started
+// sending the response, so the body is checked for an error too.
+func completeUpload(ctx context.Context, url string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{
+		Parts: parts,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	result, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("completing backup upload: unexpected status %s", resp.Status)
+	}
+
+	s3Error := struct {
+		XMLName xml.Name
+		Message string `xml:"Message"`
+	}{}
+	if err := xml.Unmarshal(result, &s3Error); err == nil && s3Error.XMLName.Local == "Error" {
+		return fmt.Errorf("completing backup upload: %s", s3Error.Message)
+	}
+	return nil
+}
+
+// Download fetches the archive from the presigned URL and replaces the contents of dir with it. The archive is
+// downloaded completely before anything in dir is touched.
+func Download(ctx context.Context, url, dir string) error {
+	f, err := os.CreateTemp("", "restore-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading backup: unexpected status %s", resp.Status)
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("downloading backup: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return Extract(f, dir)
+}
@@ -18,7 +18,10 @@ package v1
 	#ContainerBase
 	labels:                       [string]: string
 	annotations:                  [string]: string
-	scale?: >=0
+	scale?: >=0 | #Autoscale
+	availability?:    #Availability
+	rollout?:         #Rollout
+	stopGracePeriod?: #Duration
 	sidecars: [string]: #Sidecar
 }
 
@@ -26,10 +29,14 @@ package v1
 	#ContainerBase
 	labels:                       [string]: string
 	annotations:                  [string]: string
-	schedule: string | *""
+	schedule:         string | *""
+	events?: [...#JobEvent]
+	stopGracePeriod?: #Duration
 	sidecars: [string]: #Sidecar
 }
 
+#JobEvent: "create" | "update" | "delete" | "stop"
+
 #ProbeMap: {
 	[=~"ready|readiness|liveness|startup"]: string | #ProbeSpec
 }
@@ -52,6 +59,10 @@ package v1
 	tcp?: {
 		url: string
 	}
+	grpc?: {
+		url:      string
+		service?: string
+	}
 	initialDelaySeconds: uint32 | *0
 	timeoutSeconds:      uint32 | *1
 	periodSeconds:       uint32 | *10
@@ -61,6 +72,23 @@ package v1
 
 #Probes: string | #ProbeMap | [...#ProbeSpec]
 
+#LifecycleHandler: string | {
+	exec?: {
+		command: [...string]
+	}
+	http?: {
+		url: string
+		headers: [string]: string
+	}
+}
+
+#Lifecycle: {
+	postStart?: #LifecycleHandler
+	preStop?:   #LifecycleHandler
+}
+
+#Duration: =~"^([0-9]+(\\.[0-9]+)?(ns|us|ms|s|m|h))+$"
+
 #FileSecretSpec: {
 	name:     string
 	key:      string
@@ -70,7 +98,14 @@ package v1
 #FileSpec: {
 	mode: =~"^[0-7]{3,4}$" | *"0644"
 	{
-		content: string
+		content:    string
+		sensitive?: bool
+	} | {
+		base64:     string | bytes
+		sensitive?: bool
+	} | {
+		template:   string
+		sensitive?: bool
 	} | {
 		secret: #FileSecretSpec
 	}
@@ -92,12 +127,60 @@ package v1
 	ports:                          #PortSingle | *[...#Port] | #PortMap
 	[=~"probes|probe"]:             #Probes
 	[=~"depends[oO]n|depends_on"]:  string | *[...string]
+	memory?:                        #ResourceRequirement
+	cpu?:                           #ResourceRequirement
+	class?:                         string
+	security?:                      #Security
+	lifecycle?:                     #Lifecycle
 	permissions: {
 		rules: [...#RuleSpec]
 		clusterRules: [...#ClusterRuleSpec]
 	}
 }
 
+#Capability: =~"^[A-Z_]+$"
+
+#Security: {
+	runAsUser?:             int & >=0
+	runAsGroup?:            int & >=0
+	runAsNonRoot?:          bool
+	readOnlyRootFilesystem: bool | *false
+	privileged:             bool | *false
+	capabilities?: {
+		add: [...#Capability]
+		drop: [...#Capability]
+	}
+	hostNetwork: bool | *false
+	hostPID:     bool | *false
+	hostIPC:     bool | *false
+}
+
+#Autoscale: {
+	min:                int & >=1 | *1
+	max:                int & >=min
+	cpuUtilization?:    int & >0
+	memoryUtilization?: int & >0
+}
+
+#Availability: {
+	minAvailable?: (int & >=0) | =~"^[0-9]+%$"
+	spreadAcross:  *"node" | "zone" | "none"
+}
+
+#Rollout: {
+	strategy:          *"rolling" | "recreate"
+	maxSurge?:         (int & >=0) | =~"^[0-9]+%$"
+	maxUnavailable?:   (int & >=0) | =~"^[0-9]+%$"
+	progressDeadline?: #Duration
+}
+
+#ResourceQuantity: (number & >0) | string
+
+#ResourceRequirement: #ResourceQuantity | {
+	request?: #ResourceQuantity
+	limit?:   #ResourceQuantity
+}
+
 #ShortVolumeRef: "^[a-z][-a-z0-9]*$"
 #VolumeRef:      "^volume://.+$"
 #EphemeralRef:   "^ephemeral://.*$|^$"
@@ -110,7 +193,7 @@ package v1
 
 #PortSingle: (>0 & <65536) | =~#PortRegexp
 #Port:       (>0 & <65536) | =~#PortRegexp | #PortSpec
-#PortRegexp: #"^([a-z][-a-z0-9]+:)?([0-9]+:)?([a-z][-a-z0-9]+:)?([0-9]+)(/(tcp|udp|http))?$"#
+#PortRegexp: #"^([a-z][-a-z0-9]+:)?([0-9]+:)?([a-z][-a-z0-9]+:)?([0-9]+)(/(tcp|udp|http|http2|grpc))?$"#
 
 #PortSpec: {
 	publish:           bool | *false
@@ -119,7 +202,7 @@ package v1
 	targetPort:        int
 	targetServiceName: string | *""
 	serviceName:       string | *""
-	protocol:          *"" | "tcp" | "udp" | "http"
+	protocol:          *"" | "tcp" | "udp" | "http" | "http2" | "grpc"
 }
 
 // Allowing [resourceType:][resourceName:][some.random/key]
@@ -155,14 +238,59 @@ package v1
 	build?: string | *#Build
 }
 
+#AcornBuild: {
+	buildArgs: [string]: _
+	context:    string | *"."
+	acornfile?: string
+}
+
+#AcornPortMap: {
+	expose:  #PortSingle | *[...#Port]
+	publish: #PortSingle | *[...#Port]
+}
+
+#Acorn: {
+	labels:      #ScopedLabelMap | *[...#ScopedLabel]
+	annotations: #ScopedLabelMap | *[...#ScopedLabel]
+	// 1 of image or build is required
+	image?:      string
+	build?:      string | #AcornBuild
+	profiles:    [...string]
+	deployArgs:  [string]: _
+	ports:       #PortSingle | *[...#Port] | #AcornPortMap
+	volumes:     [...(string | {...})]
+	secrets:     [...(string | {...})]
+	links:       [...(string | {...})]
+	environment: #EnvVars
+}
+
 #AccessMode: "readWriteMany" | "readWriteOnce" | "readOnlyMany"
 
 #Volume: {
 	labels:      [string]: string
 	annotations: [string]: string
 	class:       string | *""
-	size:        int | *10 | string
+	size:        int | string | *#DefaultVolumeSize[medium]
 	accessModes: [#AccessMode, ...#AccessMode] | #AccessMode | *"readWriteOnce"
+	seed?:       =~#ContextDirRef | #VolumeSeed
+	// A memory backed volume is a tmpfs that counts against the memory of the containers that mount it
+	medium: *"" | "memory"
+	if medium == "memory" {
+		class: "ephemeral"
+	}
+	// Each replica of the containers mounting a per replica volume gets its own volume
+	perReplica?: bool
+}
+
+#DefaultVolumeSize: {
+	"":     10
+	memory: "64Mi"
+}
+
+#VolumeSeed: {
+	image: string
+	// The directory of the image that is copied, there is no default so that the whole image is not copied by accident
+	path?: string
 }
 
 #SecretBase: {
@@ -170,6 +298,13 @@ package v1
 	annotations:  [string]: string
 }
 
+#SecretRotation: {
+	// How often new values are generated
+	every: string
+	// How long the previous values are kept after a rotation
+	overlap: string | *"1h"
+}
+
 #SecretOpaque: {
 	#SecretBase
 	type: "opaque"
@@ -186,6 +321,7 @@ package v1
 #SecretToken: {
 	#SecretBase
 	type: "token"
+	rotation?: #SecretRotation
 	params: {
 		// The character set used in the generated string
 		characters: string | *"bcdfghjklmnpqrstvwxz2456789"
@@ -200,6 +336,7 @@ package v1
 #SecretBasicAuth: {
 	#SecretBase
 	type: "basic"
+	rotation?: #SecretRotation
 	data: {
 		username?: string
 		password?: string
@@ -216,7 +353,56 @@ package v1
 	data: {}
 }
 
-#Secret: *#SecretOpaque | #SecretBasicAuth | #SecretGenerated | #SecretTemplate | #SecretToken
+#SecretTLS: {
+	#SecretBase
+	type: "tls"
+	params: {
+		// Generate a self-signed CA instead of a certificate for the services of the app
+		ca: bool | *false
+		// The name of a tls secret with ca set to true in this app that signs the certificate
+		caSecret?: =~#DNSName
+		commonName?: string
+		// The services whose names are added to the certificate, all services of the app by default
+		services?: [...string]
+		// Additional DNS names or IP addresses added to the certificate
+		sans?: [...string]
+		algorithm:   *"ecdsa" | "rsa" | "ed25519"
+		bits:        int | *2048
+		duration:    string | *"2160h"
+		renewBefore: string | *"720h"
+	}
+	data: {}
+}
+
+#SecretRSA: {
+	#SecretBase
+	type: "rsa"
+	rotation?: #SecretRotation
+	params: {
+		bits: int | *2048
+	}
+	data: {}
+}
+
+#SecretED25519: {
+	#SecretBase
+	type: "ed25519"
+	rotation?: #SecretRotation
+	data: {}
+}
+
+#SecretSSH: {
+	#SecretBase
+	type: "ssh"
+	rotation?: #SecretRotation
+	params: {
+		algorithm: *"ed25519" | "rsa"
+		bits:      int | *2048
+	}
+	data: {}
+}
+
+#Secret: *#SecretOpaque | #SecretBasicAuth | #SecretGenerated | #SecretTemplate | #SecretToken | #SecretTLS | #SecretRSA | #SecretED25519 | #SecretSSH
 
 #Router: {
 	labels: [string]:      string
@@ -230,9 +416,39 @@ package v1
 }
 
 #RouteTarget: {
-	pathType:          "exact" | *"prefix"
+	pathType:           "exact" | *"prefix"
+	targetServiceName?: =~#DNSName
+	targetPort?:        int
+	// Split the traffic between services by weight instead of sending it to targetServiceName
+	targets?: [#WeightedTarget, ...#WeightedTarget]
+	// The cookie that keeps a client on the target it was first sent to
+	stickyCookie?: =~"^[a-zA-Z0-9_]+$"
+	// Only route requests with these methods and headers
+	match?: #RouteMatch
+	// Remove the path of the route, or replace it with rewrite, before proxying
+	stripPrefix?: bool
+	rewrite?:     =~#PathName
+	// Respond with a redirect instead of proxying
+	redirect?: string | #RouteRedirect
+	if targets == _|_ && redirect == _|_ {
+		targetServiceName: =~#DNSName
+	}
+}
+
+#RouteMatch: {
+	methods?: [...("GET" | "HEAD" | "POST" | "PUT" | "PATCH" | "DELETE" | "OPTIONS")]
+	headers?: [=~"^[a-zA-Z0-9-]+$"]: string
+}
+
+#RouteRedirect: {
+	url:  string
+	code: 301 | *302 | 303 | 307 | 308
+}
+
+#WeightedTarget: {
 	targetServiceName: =~#DNSName
 	targetPort?:       int
+	weight?:           int & >=0
 }
 
 #RouteMap: [=~#PathName]: {
@@ -241,6 +457,11 @@ package v1
 
 #RouteTargetName: "[a-z][-a-z0-9]*(:[0-9]+)?"
 
+#Service: string | {
+	address: string | *""
+	ports:   #PortSingle | *[...#Port]
+}
+
 #PathName: "/.*"
 
 #DNSName: "[a-z][-a-z0-9]*"
@@ -254,9 +475,11 @@ package v1
 	containers: [=~#DNSName]: #Container
 	jobs: [=~#DNSName]:       #Job
 	images: [=~#DNSName]:     #Image
+	acorns: [=~#DNSName]:     #Acorn
 	volumes: [=~#DNSName]:    #Volume
 	secrets: [=~#DNSName]:    #Secret
 	routers: [=~#DNSName]:    #Router
+	services: [=~#DNSName]:   #Service
 	labels: [string]:         string
 	annotations: [string]:    string
 }